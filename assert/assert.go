@@ -0,0 +1,211 @@
+// Package assert provides test assertions on the requests an awsmocker instance received.
+//
+//	m := awsmocker.Start(t, ...)
+//
+//	// run your code
+//
+//	assert.AssertCalled(t, m, "sqs", "SendMessage")
+//	assert.AssertCalledWithInput(t, m, &sqs.SendMessageInput{
+//		QueueUrl: aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/queue"),
+//	})
+package assert
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/webdestroya/awsmocker"
+)
+
+// The subset of [testing.T] that the assertions need
+type TestingT interface {
+	Errorf(format string, args ...any)
+}
+
+type tHelper interface {
+	Helper()
+}
+
+// Narrows down which received requests are considered by an assertion
+type CallOption func(*callFilter)
+
+type callFilter struct {
+	input      any
+	predicates []func(*awsmocker.ReceivedRequest) bool
+}
+
+// Only consider requests whose typed SDK input partially matches the given input.
+// Fields that are nil or the zero value in the given input are ignored.
+func WithInput(input any) CallOption {
+	return func(f *callFilter) {
+		f.input = input
+	}
+}
+
+// Only consider requests where the function returns true
+func Where(fn func(*awsmocker.ReceivedRequest) bool) CallOption {
+	return func(f *callFilter) {
+		f.predicates = append(f.predicates, fn)
+	}
+}
+
+// Only consider requests that were answered by the given mock
+func AnsweredBy(mock *awsmocker.MockedEndpoint) CallOption {
+	return Where(func(rr *awsmocker.ReceivedRequest) bool {
+		return rr.MatchedEndpoint == mock
+	})
+}
+
+func newCallFilter(opts []CallOption) *callFilter {
+	f := &callFilter{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// returns the requests that pass the filter, and the closest request that did not
+func (f *callFilter) apply(requests []*awsmocker.ReceivedRequest) ([]*awsmocker.ReceivedRequest, *nearestCall) {
+	var (
+		matched = make([]*awsmocker.ReceivedRequest, 0, len(requests))
+		nearest *nearestCall
+	)
+
+REQUESTS:
+	for i, rr := range requests {
+		for _, predicate := range f.predicates {
+			if !predicate(rr) {
+				continue REQUESTS
+			}
+		}
+
+		if f.input == nil {
+			matched = append(matched, rr)
+			continue
+		}
+
+		diffs := diffInput(f.input, rr.Input)
+		if len(diffs) == 0 {
+			matched = append(matched, rr)
+			continue
+		}
+
+		if nearest == nil || len(diffs) < len(nearest.diffs) {
+			nearest = &nearestCall{index: i, request: rr, diffs: diffs}
+		}
+	}
+
+	return matched, nearest
+}
+
+type nearestCall struct {
+	index   int
+	request *awsmocker.ReceivedRequest
+	diffs   []inputDiff
+}
+
+func (n *nearestCall) String() string {
+	if n == nil {
+		return ""
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "\nClosest call (#%d %s):\n", n.index+1, n.request.Inspect())
+	for _, d := range n.diffs {
+		fmt.Fprintf(buf, "  %s\n", d.path)
+		fmt.Fprintf(buf, "    - expected: %s\n", d.expected)
+		fmt.Fprintf(buf, "    + actual:   %s\n", d.actual)
+	}
+	return buf.String()
+}
+
+func operationName(service, action string) string {
+	switch {
+	case service == "" && action == "":
+		return "any operation"
+	case service == "":
+		return action
+	case action == "":
+		return service + ":*"
+	default:
+		return service + ":" + action
+	}
+}
+
+// Asserts that the mocker received at least one request for the service and action.
+// An empty service or action matches anything.
+func AssertCalled(t TestingT, m awsmocker.MockerInfo, service, action string, opts ...CallOption) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	matched, nearest := newCallFilter(opts).apply(m.RequestsFor(service, action))
+	if len(matched) > 0 {
+		return true
+	}
+
+	t.Errorf("Expected %s to be called, but it was not%s", operationName(service, action), nearest)
+	return false
+}
+
+// Asserts that the mocker did not receive any request for the service and action.
+// An empty service or action matches anything.
+func AssertNotCalled(t TestingT, m awsmocker.MockerInfo, service, action string, opts ...CallOption) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	matched, _ := newCallFilter(opts).apply(m.RequestsFor(service, action))
+	if len(matched) == 0 {
+		return true
+	}
+
+	t.Errorf("Expected %s to not be called, but it was called %d times", operationName(service, action), len(matched))
+	return false
+}
+
+// Asserts that the mocker received exactly the expected number of requests for the service and action.
+// An empty service or action matches anything.
+func AssertCallCount(t TestingT, m awsmocker.MockerInfo, service, action string, expected int, opts ...CallOption) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	matched, nearest := newCallFilter(opts).apply(m.RequestsFor(service, action))
+	if len(matched) == expected {
+		return true
+	}
+
+	t.Errorf("Expected %s to be called %d times, but it was called %d times%s", operationName(service, action), expected, len(matched), nearest)
+	return false
+}
+
+// Asserts that the mocker received a request whose typed SDK input partially matches the given input.
+// The operation is determined by the type of the input. Fields that are nil or the zero value are ignored.
+//
+//	assert.AssertCalledWithInput(t, m, &s3.PutObjectInput{Bucket: aws.String("my-bucket")})
+func AssertCalledWithInput(t TestingT, m awsmocker.MockerInfo, input any, opts ...CallOption) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	requests := make([]*awsmocker.ReceivedRequest, 0)
+	for _, rr := range m.Requests() {
+		if sameType(input, rr.Input) {
+			requests = append(requests, rr)
+		}
+	}
+
+	if len(requests) == 0 {
+		t.Errorf("Expected a call with %T, but there were none", input)
+		return false
+	}
+
+	matched, nearest := newCallFilter(append(opts, WithInput(input))).apply(requests)
+	if len(matched) > 0 {
+		return true
+	}
+
+	t.Errorf("Expected a call with %T matching %s, but none of the %d calls matched%s", input, describeInput(input), len(requests), nearest)
+	return false
+}
@@ -0,0 +1,176 @@
+package assert_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+	"github.com/webdestroya/awsmocker/assert"
+)
+
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func startMocker(t *testing.T) awsmocker.MockerInfo {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		awsmocker.NewSimpleMockedEndpoint("ecs", "ListClusters", map[string]any{}),
+		awsmocker.NewSimpleMockedEndpoint("ecs", "DescribeClusters", map[string]any{}),
+	))
+
+	client := ecs.NewFromConfig(m.Config())
+
+	_, err := client.DescribeClusters(context.TODO(), &ecs.DescribeClustersInput{
+		Clusters: []string{"alpha"},
+		Include:  []ecstypes.ClusterField{"TAGS"},
+	})
+	require.NoError(t, err)
+
+	_, err = client.DescribeClusters(context.TODO(), &ecs.DescribeClustersInput{
+		Clusters: []string{"beta", "gamma"},
+	})
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestAssertions(t *testing.T) {
+	m := startMocker(t)
+
+	tables := []struct {
+		name   string
+		assert func(assert.TestingT) bool
+		errors []string
+	}{
+		{
+			name: "called",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCalled(rt, m, "ecs", "DescribeClusters")
+			},
+		},
+		{
+			name: "called with filter",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCalled(rt, m, "ecs", "", assert.Where(func(rr *awsmocker.ReceivedRequest) bool {
+					return rr.Action == "DescribeClusters"
+				}))
+			},
+		},
+		{
+			name: "not called",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCalled(rt, m, "ecs", "ListClusters")
+			},
+			errors: []string{"Expected ecs:ListClusters to be called, but it was not"},
+		},
+		{
+			name: "assert not called",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertNotCalled(rt, m, "ecs", "ListClusters")
+			},
+		},
+		{
+			name: "assert not called fails",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertNotCalled(rt, m, "ecs", "")
+			},
+			errors: []string{"Expected ecs:* to not be called, but it was called 2 times"},
+		},
+		{
+			name: "call count",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCallCount(rt, m, "ecs", "DescribeClusters", 2)
+			},
+		},
+		{
+			name: "call count with input",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCallCount(rt, m, "ecs", "DescribeClusters", 1, assert.WithInput(&ecs.DescribeClustersInput{
+					Clusters: []string{"alpha"},
+				}))
+			},
+		},
+		{
+			name: "call count fails",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCallCount(rt, m, "", "DescribeClusters", 3)
+			},
+			errors: []string{"Expected DescribeClusters to be called 3 times, but it was called 2 times"},
+		},
+		{
+			name: "called with input",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCalledWithInput(rt, m, &ecs.DescribeClustersInput{
+					Clusters: []string{"beta", "gamma"},
+				})
+			},
+		},
+		{
+			name: "called with input ignores unset fields",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCalledWithInput(rt, m, &ecs.DescribeClustersInput{
+					Include: []ecstypes.ClusterField{"TAGS"},
+				})
+			},
+		},
+		{
+			name: "called with input mismatch",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCalledWithInput(rt, m, &ecs.DescribeClustersInput{
+					Clusters: []string{"alpha"},
+					Include:  []ecstypes.ClusterField{"SETTINGS"},
+				})
+			},
+			errors: []string{
+				`Expected a call with *ecs.DescribeClustersInput matching {Clusters=["alpha"], Include=["SETTINGS"]}, but none of the 2 calls matched
+Closest call (#1 ecs:DescribeClusters):
+  Include[0]
+    - expected: "SETTINGS"
+    + actual:   "TAGS"
+`,
+			},
+		},
+		{
+			name: "called with input no calls",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCalledWithInput(rt, m, &sts.GetCallerIdentityInput{})
+			},
+			errors: []string{"Expected a call with *sts.GetCallerIdentityInput, but there were none"},
+		},
+		{
+			name: "called with a different input type",
+			assert: func(rt assert.TestingT) bool {
+				return assert.AssertCalled(rt, m, "ecs", "DescribeClusters", assert.WithInput(&ecs.ListClustersInput{
+					MaxResults: aws.Int32(5),
+				}))
+			},
+			errors: []string{
+				`Expected ecs:DescribeClusters to be called, but it was not
+Closest call (#1 ecs:DescribeClusters):
+  (type)
+    - expected: *ecs.ListClustersInput
+    + actual:   *ecs.DescribeClustersInput
+`,
+			},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			rt := &recordingT{}
+			ok := table.assert(rt)
+			require.Equal(t, len(table.errors) == 0, ok)
+			require.Equal(t, table.errors, rt.errors)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// a single field where the actual input did not match the expected one
+type inputDiff struct {
+	path     string
+	expected string
+	actual   string
+}
+
+func sameType(a, b any) bool {
+	return a != nil && b != nil && reflect.TypeOf(a) == reflect.TypeOf(b)
+}
+
+// Compares the actual input against the expected one. Only the fields that are set in expected are compared.
+func diffInput(expected, actual any) []inputDiff {
+	if !sameType(expected, actual) {
+		return []inputDiff{{
+			path:     "(type)",
+			expected: fmt.Sprintf("%T", expected),
+			actual:   fmt.Sprintf("%T", actual),
+		}}
+	}
+
+	diffs := make([]inputDiff, 0)
+	diffValue(reflect.ValueOf(expected), reflect.ValueOf(actual), "", &diffs)
+	return diffs
+}
+
+func diffValue(expected, actual reflect.Value, path string, diffs *[]inputDiff) {
+	if isUnset(expected) {
+		return
+	}
+
+	addDiff := func() {
+		*diffs = append(*diffs, inputDiff{
+			path:     coalescePath(path),
+			expected: formatValue(expected),
+			actual:   formatValue(actual),
+		})
+	}
+
+	switch expected.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if actual.IsNil() {
+			addDiff()
+			return
+		}
+		if expected.Kind() == reflect.Interface && expected.Elem().Type() != actual.Elem().Type() {
+			addDiff()
+			return
+		}
+		diffValue(expected.Elem(), actual.Elem(), path, diffs)
+
+	case reflect.Struct:
+		for i := 0; i < expected.NumField(); i++ {
+			field := expected.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			diffValue(expected.Field(i), actual.Field(i), joinPath(path, field.Name), diffs)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if expected.Len() != actual.Len() {
+			addDiff()
+			return
+		}
+		for i := 0; i < expected.Len(); i++ {
+			diffValue(expected.Index(i), actual.Index(i), fmt.Sprintf("%s[%d]", path, i), diffs)
+		}
+
+	case reflect.Map:
+		keys := expected.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, key := range keys {
+			keyPath := fmt.Sprintf("%s[%v]", path, key)
+			actualValue := actual.MapIndex(key)
+			if !actualValue.IsValid() {
+				*diffs = append(*diffs, inputDiff{
+					path:     keyPath,
+					expected: formatValue(expected.MapIndex(key)),
+					actual:   "<absent>",
+				})
+				continue
+			}
+			diffValue(expected.MapIndex(key), actualValue, keyPath, diffs)
+		}
+
+	default:
+		if !reflect.DeepEqual(expected.Interface(), actual.Interface()) {
+			addDiff()
+		}
+	}
+}
+
+// nil and zero values in the expected input are not compared
+func isUnset(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero()
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func coalescePath(path string) string {
+	if path == "" {
+		return "(value)"
+	}
+	return path
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "<nil>"
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() == reflect.String:
+		items := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, fmt.Sprintf("%q", v.Index(i).String()))
+		}
+		return "[" + strings.Join(items, " ") + "]"
+	}
+
+	if !v.CanInterface() {
+		return v.Type().String()
+	}
+
+	return fmt.Sprintf("%+v", v.Interface())
+}
+
+// renders the fields that are set in the input
+func describeInput(input any) string {
+	parts := make([]string, 0)
+	collectSetFields(reflect.ValueOf(input), "", &parts)
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func collectSetFields(v reflect.Value, path string, parts *[]string) {
+	if isUnset(v) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		collectSetFields(v.Elem(), path, parts)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			collectSetFields(v.Field(i), joinPath(path, field.Name), parts)
+		}
+	default:
+		*parts = append(*parts, coalescePath(path)+"="+formatValue(v))
+	}
+}
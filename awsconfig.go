@@ -3,6 +3,7 @@ package awsmocker
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,6 +12,40 @@ import (
 	// awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 )
 
+const (
+	fakeAccessKeyId  = "XXfakekey"
+	fakeSecretKey    = "XXfakesecret"
+	fakeSessionToken = "xxtoken"
+)
+
+// Returns the account encoded in an access key from the mocker config
+func accountIdFromAccessKey(accessKeyId string) string {
+	if accessKeyId == fakeAccessKeyId {
+		return DefaultAccountId
+	}
+
+	if accountId, ok := strings.CutPrefix(accessKeyId, fakeAccessKeyId+"-"); ok {
+		return accountId
+	}
+
+	return ""
+}
+
+// Returns a copy of the mocker config that uses the given region
+func (m *mocker) ConfigForRegion(region string) aws.Config {
+	cfg := m.Config().Copy()
+	cfg.Region = region
+	return cfg
+}
+
+// Returns a copy of the mocker config with credentials for the given account.
+// Requests made with it will have the account set in [ReceivedRequest.AccountId]
+func (m *mocker) ConfigForAccount(accountId string) aws.Config {
+	cfg := m.Config().Copy()
+	cfg.Credentials = aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(fakeAccessKeyId+"-"+accountId, fakeSecretKey, fakeSessionToken))
+	return cfg
+}
+
 // If your application is setup to where you can provide an aws.Config object for your clients,
 // then using the one provided by this method will make testing much easier.
 func (m *mocker) buildAwsConfig(opts ...AwsLoadOptionsFunc) aws.Config {
@@ -33,7 +68,7 @@ func (m *mocker) buildAwsConfig(opts ...AwsLoadOptionsFunc) aws.Config {
 	options := make([]AwsLoadOptionsFunc, 0, 15)
 
 	options = append(options, config.WithDisableRequestCompression(aws.Bool(true)))
-	options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("XXfakekey", "XXfakesecret", "xxtoken")))
+	options = append(options, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(fakeAccessKeyId, fakeSecretKey, fakeSessionToken)))
 	options = append(options, config.WithDefaultRegion(DefaultRegion))
 	// options = append(options, config.WithHTTPClient(httpClient))
 	// options = append(options, config.WithHTTPClient(m))
@@ -46,7 +81,9 @@ func (m *mocker) buildAwsConfig(opts ...AwsLoadOptionsFunc) aws.Config {
 	// apply the options the user wanted
 	options = append(options, opts...)
 
-	options = append(options, addMiddlewareConfigOption(m))
+	if !m.noMiddleware {
+		options = append(options, addMiddlewareConfigOption(m))
+	}
 
 	cfg, err := config.LoadDefaultConfig(context.TODO(), options...)
 	if err != nil {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/stretchr/testify/require"
 	"github.com/webdestroya/awsmocker"
@@ -17,3 +19,72 @@ func TestAwsConfigBuilder(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValuesf(t, awsmocker.DefaultAccountId, *resp.Account, "account id mismatch")
 }
+
+func TestConfigForRegionAndAccount(t *testing.T) {
+	clusterMock := func(req *awsmocker.MockedRequest, arn string) *awsmocker.MockedEndpoint {
+		req.Service = "ecs"
+		req.Action = "ListClusters"
+		return &awsmocker.MockedEndpoint{
+			Request: req,
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{"clusterArns": []string{arn}},
+			},
+		}
+	}
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		clusterMock(&awsmocker.MockedRequest{Region: "us-east-1", AccountId: awsmocker.DefaultAccountId}, "default"),
+		clusterMock(&awsmocker.MockedRequest{RegionMatch: awsmocker.Prefix("eu-")}, "europe"),
+		clusterMock(&awsmocker.MockedRequest{AccountId: "111111111111"}, "other-account"),
+	))
+
+	tables := []struct {
+		cfg      aws.Config
+		region   string
+		expected string
+	}{
+		{m.Config(), "us-east-1", "default"},
+		{m.ConfigForRegion("eu-west-1"), "eu-west-1", "europe"},
+		{m.ConfigForRegion("eu-central-1"), "eu-central-1", "europe"},
+		{m.ConfigForAccount("111111111111"), "us-east-1", "other-account"},
+	}
+
+	for _, table := range tables {
+		resp, err := ecs.NewFromConfig(table.cfg).ListClusters(context.TODO(), &ecs.ListClustersInput{})
+		require.NoError(t, err)
+		require.Equal(t, []string{table.expected}, resp.ClusterArns)
+	}
+
+	requests := m.Requests()
+	require.Len(t, requests, len(tables))
+	for i, table := range tables {
+		require.Equal(t, table.region, requests[i].Region)
+	}
+	require.Equal(t, awsmocker.DefaultAccountId, requests[0].AccountId)
+	require.Equal(t, "111111111111", requests[3].AccountId)
+
+	// the original config is not changed
+	require.Equal(t, awsmocker.DefaultRegion, m.Config().Region)
+}
+
+func TestConfigForAccount_GetCallerIdentity(t *testing.T) {
+	m := awsmocker.Start(t)
+
+	for _, accountId := range []string{awsmocker.DefaultAccountId, "111111111111"} {
+		cfg := m.Config()
+		if accountId != awsmocker.DefaultAccountId {
+			cfg = m.ConfigForAccount(accountId)
+		}
+
+		resp, err := sts.NewFromConfig(cfg).GetCallerIdentity(context.TODO(), nil)
+		require.NoError(t, err)
+		require.Equal(t, accountId, *resp.Account)
+		require.Equal(t, "arn:aws:iam::"+accountId+":user/fakeuser", *resp.Arn)
+	}
+
+	// also without the middleware, where the body function is not called with the typed input
+	m = awsmocker.Start(t, awsmocker.WithoutMiddleware())
+	resp, err := sts.NewFromConfig(m.ConfigForAccount("222222222222")).GetCallerIdentity(context.TODO(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "222222222222", *resp.Account)
+}
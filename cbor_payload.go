@@ -0,0 +1,69 @@
+package awsmocker
+
+import (
+	"encoding/base64"
+	"math"
+	"regexp"
+
+	"github.com/aws/smithy-go/encoding/cbor"
+)
+
+const (
+	// The value of the Smithy-Protocol header for the RPCv2 CBOR protocol
+	smithyProtocolRpcV2Cbor = "rpc-v2-cbor"
+
+	// the CBOR tag for epoch-seconds timestamps
+	cborTagEpochTime = 1
+)
+
+// RPCv2 CBOR requests are sent to /service/{ServiceName}/operation/{OperationName}
+var rpcV2CborPathRegexp = regexp.MustCompile(`/service/([^/]+)/operation/([^/]+)$`)
+
+// Decodes a CBOR document into the same form it would have if it had been decoded from JSON.
+// Numbers become float64, blobs are base64 strings and timestamps are epoch seconds.
+func decodeCborPayload(data []byte) (any, error) {
+	value, err := cbor.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return fromCborValue(value), nil
+}
+
+func fromCborValue(value cbor.Value) any {
+	switch v := value.(type) {
+	case cbor.Uint:
+		return float64(v)
+	case cbor.NegInt:
+		if v == 0 {
+			return -math.Pow(2, 64)
+		}
+		return -float64(v)
+	case cbor.Float32:
+		return float64(v)
+	case cbor.Float64:
+		return float64(v)
+	case cbor.String:
+		return string(v)
+	case cbor.Slice:
+		return base64.StdEncoding.EncodeToString(v)
+	case cbor.Bool:
+		return bool(v)
+	case cbor.List:
+		result := make([]any, 0, len(v))
+		for _, item := range v {
+			result = append(result, fromCborValue(item))
+		}
+		return result
+	case cbor.Map:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = fromCborValue(item)
+		}
+		return result
+	case *cbor.Tag:
+		return fromCborValue(v.Value)
+	default:
+		// Nil and Undefined
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package awsmocker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/encoding/cbor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCborRoundTrip(t *testing.T) {
+	body := struct {
+		Name      *string
+		Count     int32
+		Offset    int
+		Ratio     float64
+		Enabled   bool
+		Data      []byte
+		Created   time.Time
+		Tags      map[string]string
+		Values    []string
+		Missing   *string
+		unexposed string
+	}{
+		Name:    aws.String("metric"),
+		Count:   3,
+		Offset:  -5,
+		Ratio:   0.5,
+		Enabled: true,
+		Data:    []byte("hi"),
+		Created: time.Unix(1700000000, 500000000),
+		Tags:    map[string]string{"env": "test"},
+		Values:  []string{"a", "b"},
+	}
+
+	value, err := toCborValue(reflect.ValueOf(body))
+	require.NoError(t, err)
+
+	decoded, err := decodeCborPayload(cbor.Encode(value))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"Name":    "metric",
+		"Count":   float64(3),
+		"Offset":  float64(-5),
+		"Ratio":   0.5,
+		"Enabled": true,
+		"Data":    "aGk=",
+		"Created": 1700000000.5,
+		"Tags":    map[string]any{"env": "test"},
+		"Values":  []any{"a", "b"},
+	}, decoded)
+
+	_, err = toCborValue(reflect.ValueOf(map[string]any{"fn": func() {}}))
+	require.ErrorContains(t, err, "fn: cannot encode a kind=func")
+
+	_, err = decodeCborPayload([]byte{0xff})
+	require.Error(t, err)
+}
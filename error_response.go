@@ -4,6 +4,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"net/http"
+
+	"github.com/aws/smithy-go/encoding/cbor"
 )
 
 // aws/protocol/restjson/decoder_util.go
@@ -15,6 +17,9 @@ type errorResponse struct {
 	Code    string `xml:"Error>Code" json:"code"`
 	Message string `xml:"Error>Message" json:"message"`
 
+	// The namespace qualified error shape, used by the AWS JSON protocols
+	JsonType string `xml:"-" json:"__type,omitempty"`
+
 	RequestId string `xml:"RequestId" json:"-"`
 
 	statusCode int `xml:"-" json:"-"`
@@ -29,12 +34,36 @@ func (e *errorResponse) getResponse(rr *ReceivedRequest) *httpResponse {
 
 	switch rr.AssumedResponseType {
 	case ContentTypeJSON:
+		jsonErr := *e
+		jsonErr.JsonType = rr.jsonErrorType(e.Code)
 		return &httpResponse{
-			contentType: ContentTypeJSON,
-			Body:        EncodeAsJson(e),
+			contentType: rr.jsonContentType(),
+			Body:        EncodeAsJson(&jsonErr),
 			StatusCode:  e.statusCode, // 501
+			extraHeaders: map[string]string{
+				"X-Amzn-Errortype": e.Code,
+			},
+		}
+	case ContentTypeCBOR:
+		return &httpResponse{
+			contentType: ContentTypeCBOR,
+			bodyRaw: cbor.Encode(cbor.Map{
+				"__type":  cbor.String(rr.jsonErrorType(e.Code)),
+				"message": cbor.String(e.Message),
+			}),
+			StatusCode: e.statusCode,
+			extraHeaders: map[string]string{
+				"Smithy-Protocol": smithyProtocolRpcV2Cbor,
+			},
 		}
 	case ContentTypeXML:
+		if rr.Service == "ec2" {
+			return &httpResponse{
+				contentType: ContentTypeXML,
+				Body:        encodeAsXml(&ec2ErrorResponse{Code: e.Code, Message: e.Message, RequestId: e.RequestId}),
+				StatusCode:  e.statusCode,
+			}
+		}
 		return &httpResponse{
 			contentType: ContentTypeXML,
 			Body:        encodeAsXml(e),
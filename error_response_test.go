@@ -15,4 +15,37 @@ func TestErrorResponse_getResponse(t *testing.T) {
 		require.Equal(t, ContentTypeXML, hr.contentType)
 		require.Contains(t, hr.Body, "<Type>Sender</Type>")
 	})
+
+	t.Run("JSON", func(t *testing.T) {
+		er := generateErrorStruct(400, "ResourceNotFoundException", "Requested resource not found")
+
+		hr := er.getResponse(&ReceivedRequest{Service: "dynamodb", AssumedResponseType: ContentTypeJSON, awsJsonContentType: ContentTypeJSON10})
+		require.Equal(t, 400, hr.StatusCode)
+		require.Equal(t, ContentTypeJSON10, hr.contentType)
+		require.Equal(t, "ResourceNotFoundException", hr.extraHeaders["X-Amzn-Errortype"])
+		require.JSONEq(t, `{"__type":"com.amazonaws.dynamodb.v20120810#ResourceNotFoundException","code":"ResourceNotFoundException","message":"Requested resource not found"}`, hr.Body)
+		require.Empty(t, er.JsonType)
+	})
+
+	t.Run("JSONUnknownNamespace", func(t *testing.T) {
+		er := generateErrorStruct(400, "ClusterNotFoundException", "Cluster not found.")
+
+		hr := er.getResponse(&ReceivedRequest{Service: "ecs", AssumedResponseType: ContentTypeJSON})
+		require.JSONEq(t, `{"__type":"ClusterNotFoundException","code":"ClusterNotFoundException","message":"Cluster not found."}`, hr.Body)
+	})
+
+	t.Run("JSONQualifiedCode", func(t *testing.T) {
+		er := generateErrorStruct(400, "com.amazonaws.ecs#ClusterNotFoundException", "Cluster not found.")
+
+		hr := er.getResponse(&ReceivedRequest{Service: "dynamodb", AssumedResponseType: ContentTypeJSON})
+		require.Contains(t, hr.Body, `"__type":"com.amazonaws.ecs#ClusterNotFoundException"`)
+	})
+
+	t.Run("JSONWithoutService", func(t *testing.T) {
+		er := generateErrorStruct(400, "Thing", "msg")
+
+		hr := er.getResponse(&ReceivedRequest{AssumedResponseType: ContentTypeJSON})
+		require.Equal(t, ContentTypeJSON, hr.contentType)
+		require.JSONEq(t, `{"__type":"Thing","code":"Thing","message":"msg"}`, hr.Body)
+	})
 }
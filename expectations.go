@@ -0,0 +1,72 @@
+package awsmocker
+
+import "fmt"
+
+// The number of times a mock is expected to be matched. A max of -1 means there is no upper limit.
+type callExpectation struct {
+	min int
+	max int
+}
+
+func (e *callExpectation) met(count int) bool {
+	return count >= e.min && (e.max < 0 || count <= e.max)
+}
+
+func (e *callExpectation) String() string {
+	switch {
+	case e.min == e.max:
+		return fmt.Sprintf("exactly %d", e.min)
+	case e.max < 0:
+		return fmt.Sprintf("at least %d", e.min)
+	case e.min == 0:
+		return fmt.Sprintf("at most %d", e.max)
+	default:
+		return fmt.Sprintf("between %d and %d", e.min, e.max)
+	}
+}
+
+// Expect this endpoint to be matched exactly n times. This is checked when the test finishes.
+func (m *MockedEndpoint) Times(n int) *MockedEndpoint {
+	m.expectedCalls = &callExpectation{min: n, max: n}
+	return m
+}
+
+// Expect this endpoint to be matched at least n times. This is checked when the test finishes.
+//
+// This can be combined with [MockedEndpoint.AtMost] to expect a range.
+func (m *MockedEndpoint) AtLeast(n int) *MockedEndpoint {
+	if m.expectedCalls == nil {
+		m.expectedCalls = &callExpectation{max: -1}
+	}
+	m.expectedCalls.min = n
+	return m
+}
+
+// Expect this endpoint to be matched at most n times. This is checked when the test finishes.
+//
+// This can be combined with [MockedEndpoint.AtLeast] to expect a range.
+func (m *MockedEndpoint) AtMost(n int) *MockedEndpoint {
+	if m.expectedCalls == nil {
+		m.expectedCalls = &callExpectation{}
+	}
+	m.expectedCalls.max = n
+	return m
+}
+
+// Expect this endpoint to never be matched. This is checked when the test finishes.
+func (m *MockedEndpoint) Never() *MockedEndpoint {
+	return m.Times(0)
+}
+
+// reports any mocks whose call count expectations were not met
+func (m *mocker) verifyExpectations() {
+	for _, mock := range m.activeMocks() {
+		if mock.expectedCalls == nil {
+			continue
+		}
+
+		if count := mock.Request.MatchCount(); !mock.expectedCalls.met(count) {
+			m.t.Errorf("Expected mock %s to be called %s times, but it was called %d times", mock.Request.Inspect(), mock.expectedCalls, count)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package awsmocker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	eventbridgeTypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestCallExpectations(t *testing.T) {
+	putEventsMock := func() *awsmocker.MockedEndpoint {
+		return awsmocker.NewSimpleMockedEndpoint("events", "PutEvents", map[string]any{
+			"FailedEntryCount": 0,
+		})
+	}
+
+	tables := []struct {
+		name     string
+		mock     *awsmocker.MockedEndpoint
+		calls    int
+		expected string
+	}{
+		{"TimesMet", putEventsMock().Times(1), 1, ""},
+		{"TimesUnder", putEventsMock().Times(2), 1, "to be called exactly 2 times, but it was called 1 times"},
+		{"TimesOver", putEventsMock().Times(1), 2, "to be called exactly 1 times, but it was called 2 times"},
+		{"AtLeastMet", putEventsMock().AtLeast(1), 3, ""},
+		{"AtLeastUnder", putEventsMock().AtLeast(2), 1, "to be called at least 2 times, but it was called 1 times"},
+		{"AtMostMet", putEventsMock().AtMost(2), 2, ""},
+		{"AtMostOver", putEventsMock().AtMost(1), 2, "to be called at most 1 times, but it was called 2 times"},
+		{"RangeMet", putEventsMock().AtLeast(1).AtMost(3), 2, ""},
+		{"RangeUnder", putEventsMock().AtLeast(1).AtMost(3), 0, "to be called between 1 and 3 times, but it was called 0 times"},
+		{"NeverMet", putEventsMock().Never(), 0, ""},
+		{"NeverCalled", putEventsMock().Never(), 1, "to be called exactly 0 times, but it was called 1 times"},
+	}
+
+	for _, table := range tables {
+		var tm *TestingMock
+
+		t.Run(table.name, func(t *testing.T) {
+			tm = NewTestingMock(t)
+
+			m := awsmocker.Start(tm, awsmocker.WithMocks(table.mock))
+			client := eventbridge.NewFromConfig(m.Config())
+
+			for range table.calls {
+				_, err := client.PutEvents(context.TODO(), &eventbridge.PutEventsInput{
+					Entries: []eventbridgeTypes.PutEventsRequestEntry{
+						{Detail: aws.String("{}")},
+					},
+				})
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, table.calls, table.mock.Request.MatchCount())
+		})
+
+		// expectations are checked during cleanup, which has run by now
+		if table.expected == "" {
+			require.Falsef(t, tm.errored, "%s: unexpected errors: %v", table.name, tm.errorMessages)
+		} else {
+			require.Truef(t, tm.errored, "%s: expected an error", table.name)
+			require.Contains(t, tm.errorMessages[0], "Expected mock MReq<Service=events Action=PutEvents> "+table.expected)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package awsmocker
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Rebuilds the flattened parameters used by the query and EC2 protocols into a nested structure.
+//
+//	Filter.1.Name=vpc-id&Filter.1.Value.1=vpc-123
+//
+// becomes
+//
+//	{"Filter": [{"Name": "vpc-id", "Value": ["vpc-123"]}]}
+//
+// Lists are also available under their plural name (Filters, Values, Addresses, Policies), as that is what the SDK input uses.
+// Query protocol "member" lists and "entry" maps are unwrapped. All values are strings.
+func decodeFormPayload(form url.Values) map[string]any {
+	root := make(map[string]any)
+	for _, key := range slices.Sorted(maps.Keys(form)) {
+		setFormValue(root, strings.Split(key, "."), form.Get(key))
+	}
+	return finalizeFormNode(root).(map[string]any)
+}
+
+func setFormValue(node map[string]any, path []string, value string) {
+	if len(path) == 1 {
+		node[path[0]] = value
+		return
+	}
+
+	child, ok := node[path[0]].(map[string]any)
+	if !ok {
+		child = make(map[string]any)
+		node[path[0]] = child
+	}
+	setFormValue(child, path[1:], value)
+}
+
+// converts index keyed maps into lists, and unwraps member lists and entry maps
+func finalizeFormNode(value any) any {
+	node, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+
+	if indexes, ok := formListIndexes(node); ok {
+		list := make([]any, 0, len(indexes))
+		for _, idx := range indexes {
+			list = append(list, finalizeFormNode(node[strconv.Itoa(idx)]))
+		}
+		return list
+	}
+
+	if len(node) == 1 {
+		if member, ok := node["member"]; ok {
+			return finalizeFormNode(member)
+		}
+
+		if entry, ok := node["entry"]; ok {
+			if entries, ok := finalizeFormNode(entry).([]any); ok {
+				if m, ok := formEntriesToMap(entries); ok {
+					return m
+				}
+			}
+		}
+	}
+
+	result := make(map[string]any, len(node))
+	for k, v := range node {
+		result[k] = finalizeFormNode(v)
+	}
+
+	// EC2 uses singular names for lists (Filter.1), while the SDK uses the plural (Filters)
+	for k, v := range result {
+		if _, isList := v.([]any); !isList {
+			continue
+		}
+		plural, ok := formPluralName(k)
+		if !ok {
+			continue
+		}
+		if _, exists := result[plural]; !exists {
+			result[plural] = v
+		}
+	}
+
+	return result
+}
+
+// The plural of a singular list name, the reverse of the rules used by ec2MemberName.
+// Names that end in a single "s" are assumed to already be plural (query protocol lists use the SDK name).
+func formPluralName(name string) (string, bool) {
+	switch {
+	case name == "":
+		return "", false
+	case strings.HasSuffix(name, "ss"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es", true
+	case strings.HasSuffix(name, "s"):
+		return "", false
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsRune("aeiouAEIOU", rune(name[len(name)-2])):
+		return strings.TrimSuffix(name, "y") + "ies", true
+	default:
+		return name + "s", true
+	}
+}
+
+// returns the sorted indexes if every key of the node is a list index
+func formListIndexes(node map[string]any) ([]int, bool) {
+	if len(node) == 0 {
+		return nil, false
+	}
+
+	indexes := make([]int, 0, len(node))
+	for k := range node {
+		idx, err := strconv.Atoi(k)
+		if err != nil || idx < 1 {
+			return nil, false
+		}
+		indexes = append(indexes, idx)
+	}
+	slices.Sort(indexes)
+	return indexes, true
+}
+
+// converts a list of entry.N.key/entry.N.value pairs into a map
+func formEntriesToMap(entries []any) (map[string]any, bool) {
+	result := make(map[string]any, len(entries))
+	for _, e := range entries {
+		entry, ok := e.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		key, ok := entry["key"].(string)
+		if !ok {
+			key, ok = entry["Name"].(string)
+		}
+		if !ok {
+			return nil, false
+		}
+
+		value, ok := entry["value"]
+		if !ok {
+			value = entry["Value"]
+		}
+		result[key] = value
+	}
+	return result, true
+}
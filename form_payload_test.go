@@ -0,0 +1,115 @@
+package awsmocker
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeFormPayload(t *testing.T) {
+	tables := []struct {
+		name     string
+		form     string
+		expected map[string]any
+	}{
+		{
+			name:     "Flat",
+			form:     "Action=GetCallerIdentity&Version=2011-06-15",
+			expected: map[string]any{"Action": "GetCallerIdentity", "Version": "2011-06-15"},
+		},
+		{
+			name: "EC2Filters",
+			form: "Filter.1.Name=vpc-id&Filter.1.Value.1=vpc-123&Filter.2.Name=tag:Env&Filter.2.Value.1=a&Filter.2.Value.2=b",
+			expected: func() map[string]any {
+				filters := []any{
+					map[string]any{"Name": "vpc-id", "Value": []any{"vpc-123"}, "Values": []any{"vpc-123"}},
+					map[string]any{"Name": "tag:Env", "Value": []any{"a", "b"}, "Values": []any{"a", "b"}},
+				}
+				return map[string]any{"Filter": filters, "Filters": filters}
+			}(),
+		},
+		{
+			name: "IndexOrder",
+			form: "SubnetId.10=j&SubnetId.2=b&SubnetId.1=a",
+			expected: map[string]any{
+				"SubnetId":  []any{"a", "b", "j"},
+				"SubnetIds": []any{"a", "b", "j"},
+			},
+		},
+		{
+			name: "IrregularPlurals",
+			form: "PublicIp.1=1.2.3.4&Policy.1=a",
+			expected: map[string]any{
+				"PublicIp":  []any{"1.2.3.4"},
+				"PublicIps": []any{"1.2.3.4"},
+				"Policy":    []any{"a"},
+				"Policies":  []any{"a"},
+			},
+		},
+		{
+			name: "QueryMembers",
+			form: "Tags.member.1.Key=Env&Tags.member.1.Value=prod&PolicyArns.member.1.arn=arn:policy",
+			expected: map[string]any{
+				"Tags":       []any{map[string]any{"Key": "Env", "Value": "prod"}},
+				"PolicyArns": []any{map[string]any{"arn": "arn:policy"}},
+			},
+		},
+		{
+			name: "QueryEntries",
+			form: "Attributes.entry.1.key=DelaySeconds&Attributes.entry.1.value=5&Attributes.entry.2.key=Policy&Attributes.entry.2.value=x",
+			expected: map[string]any{
+				"Attributes": map[string]any{"DelaySeconds": "5", "Policy": "x"},
+			},
+		},
+	}
+
+	for _, table := range tables {
+		form, err := url.ParseQuery(table.form)
+		require.NoError(t, err)
+		require.Equal(t, table.expected, decodeFormPayload(form), table.name)
+	}
+}
+
+func TestFormPluralName(t *testing.T) {
+	tables := []struct {
+		name   string
+		plural string
+	}{
+		{"Filter", "Filters"},
+		{"Value", "Values"},
+		{"InstanceId", "InstanceIds"},
+		{"Policy", "Policies"},
+		{"Key", "Keys"},
+		{"Address", "Addresses"},
+		{"Prefix", "Prefixes"},
+		{"Tags", ""},
+		{"PolicyArns", ""},
+	}
+
+	for _, table := range tables {
+		plural, ok := formPluralName(table.name)
+		require.Equal(t, table.plural != "", ok, table.name)
+		require.Equal(t, table.plural, plural, table.name)
+	}
+}
+
+func TestFormPayloadJMESPath(t *testing.T) {
+	form, err := url.ParseQuery("Action=DescribeSubnets&Filter.1.Name=vpc-id&Filter.1.Value.1=vpc-123&Filter.2.Name=state&Filter.2.Value.1=available")
+	require.NoError(t, err)
+
+	mr := &MockedRequest{
+		JMESPathMatches: map[string]any{
+			"Filters[?Name=='vpc-id'].Values[0] | [0]": "vpc-123",
+			"length(Filters)":                          2,
+		},
+	}
+
+	require.True(t, mr.matchRequest(&ReceivedRequest{FormPayload: decodeFormPayload(form)}))
+
+	form.Set("Filter.1.Value.1", "vpc-456")
+	require.False(t, mr.matchRequest(&ReceivedRequest{FormPayload: decodeFormPayload(form)}))
+
+	checks := mr.checkRequest(&ReceivedRequest{}, false)
+	require.Equal(t, []matchCheck{{field: "JMESPathMatches", expected: "a JSON, CBOR, form or XML payload", actual: "<none>"}}, checks.checks)
+}
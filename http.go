@@ -12,6 +12,12 @@ func (m *mocker) handleHttp(w http.ResponseWriter, r *http.Request) {
 	origBody := resp.Body
 	defer origBody.Close()
 
+	for k, vlist := range resp.Header {
+		for _, v := range vlist {
+			w.Header().Add(k, v)
+		}
+	}
+
 	w.WriteHeader(resp.StatusCode)
 	_, _ = io.Copy(w, resp.Body)
 	if err := resp.Body.Close(); err != nil {
@@ -71,6 +71,11 @@ func Mock_IMDS_Common(optFns ...IMDSMockOptionFunc) []*MockedEndpoint {
 	mocks = append(mocks, Mock_IMDS_IAM_Credentials(cfg.RoleName))
 	mocks = append(mocks, Mock_IMDS_API_Token())
 
+	// these are a general purpose set, so it is fine if some are never used
+	for _, mock := range mocks {
+		mock.Optional = true
+	}
+
 	return mocks
 }
 
@@ -65,6 +65,12 @@ func jmesValueNormalize(value any) any {
 // the value returned from the jmes expression should equal the expected value
 // all numerical values will be casted to a float64 (as that is what json numbers are treated as)
 func JMESMatch(obj any, expression string, expected any) bool {
+	ok, _ := jmesMatchResult(obj, expression, expected)
+	return ok
+}
+
+// same as [JMESMatch], but also returns the value that the expression resolved to
+func jmesMatchResult(obj any, expression string, expected any) (bool, any) {
 
 	resp, err := jmespath.Search(expression, obj)
 	if err != nil {
@@ -75,8 +81,16 @@ func JMESMatch(obj any, expression string, expected any) bool {
 
 	funcCheck, ok := exp.(func(any) bool)
 	if ok {
-		return funcCheck(resp)
+		return funcCheck(resp), resp
 	}
 
-	return reflect.DeepEqual(resp, exp)
+	return reflect.DeepEqual(resp, exp), resp
+}
+
+// formats an expected JMES value for display
+func inspectJMESValue(expected any) string {
+	if _, ok := expected.(func(any) bool); ok {
+		return "<func>"
+	}
+	return EncodeAsJson(expected)
 }
@@ -0,0 +1,86 @@
+package awsmocker
+
+import (
+	"sync"
+)
+
+// every request the mocker received, in the order they arrived
+type requestJournal struct {
+	mu       sync.Mutex
+	requests []*ReceivedRequest
+}
+
+func newRequestJournal() *requestJournal {
+	return &requestJournal{
+		requests: make([]*ReceivedRequest, 0, 10),
+	}
+}
+
+func (j *requestJournal) record(rr *ReceivedRequest) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.requests = append(j.requests, rr)
+}
+
+// sets fields of a request after it was recorded. Recorded requests can be read from other
+// goroutines, so their fields must not be set directly
+func (j *requestJournal) update(fn func()) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn()
+}
+
+// returns copies of the recorded requests, so they can be read while requests are still being handled
+func (j *requestJournal) all() []*ReceivedRequest {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	requests := make([]*ReceivedRequest, 0, len(j.requests))
+	for _, rr := range j.requests {
+		snapshot := *rr
+		requests = append(requests, &snapshot)
+	}
+	return requests
+}
+
+func (j *requestJournal) reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.requests = j.requests[:0]
+}
+
+// Returns every request the mocker received, in the order they arrived. This includes requests that did not match a mock.
+//
+// Each request is a snapshot. A request that is still being handled will not have its MatchedEndpoint or StatusCode set yet.
+func (m *mocker) Requests() []*ReceivedRequest {
+	return m.journal.all()
+}
+
+// Returns the received requests for the given service and action. An empty value matches anything.
+func (m *mocker) RequestsFor(service, action string) []*ReceivedRequest {
+	requests := m.journal.all()
+	filtered := make([]*ReceivedRequest, 0, len(requests))
+	for _, rr := range requests {
+		if service != "" && rr.Service != service {
+			continue
+		}
+		if action != "" && rr.Action != action {
+			continue
+		}
+		filtered = append(filtered, rr)
+	}
+	return filtered
+}
+
+// Returns the typed inputs of every received request whose input is a T, in the order they arrived.
+//
+//	inputs := awsmocker.CallsOf[*sqs.SendMessageInput](m)
+func CallsOf[T any](m MockerInfo) []T {
+	calls := make([]T, 0)
+	for _, rr := range m.Requests() {
+		if input, ok := rr.Input.(T); ok {
+			calls = append(calls, input)
+		}
+	}
+	return calls
+}
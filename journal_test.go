@@ -0,0 +1,97 @@
+package awsmocker_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestRequestJournal(t *testing.T) {
+	listMock := awsmocker.NewSimpleMockedEndpoint("ecs", "ListClusters", map[string]any{})
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutFailingUnhandledRequests(), awsmocker.WithMocks(listMock))
+
+	client := ecs.NewFromConfig(m.Config())
+
+	_, err := client.ListClusters(context.TODO(), &ecs.ListClustersInput{MaxResults: aws.Int32(5)})
+	require.NoError(t, err)
+
+	_, err = client.DescribeClusters(context.TODO(), &ecs.DescribeClustersInput{Clusters: []string{"unmocked"}})
+	require.Error(t, err)
+
+	_, err = sts.NewFromConfig(m.Config()).GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
+	require.Error(t, err)
+
+	requests := m.Requests()
+	require.Len(t, requests, 3)
+
+	require.Equal(t, "ListClusters", requests[0].Action)
+	require.Same(t, listMock, requests[0].MatchedEndpoint)
+	require.Equal(t, http.StatusOK, requests[0].StatusCode)
+	require.IsType(t, &ecs.ListClustersInput{}, requests[0].Input)
+
+	require.Equal(t, "DescribeClusters", requests[1].Action)
+	require.Nil(t, requests[1].MatchedEndpoint)
+	require.Equal(t, http.StatusNotImplemented, requests[1].StatusCode)
+
+	require.Len(t, m.RequestsFor("ecs", ""), 2)
+	require.Len(t, m.RequestsFor("", "GetCallerIdentity"), 1)
+	require.Len(t, m.RequestsFor("ecs", "GetCallerIdentity"), 0)
+
+	listCalls := awsmocker.CallsOf[*ecs.ListClustersInput](m)
+	require.Len(t, listCalls, 1)
+	require.EqualValues(t, 5, *listCalls[0].MaxResults)
+
+	describeCalls := awsmocker.CallsOf[*ecs.DescribeClustersInput](m)
+	require.Len(t, describeCalls, 1)
+	require.Equal(t, []string{"unmocked"}, describeCalls[0].Clusters)
+
+	require.Empty(t, awsmocker.CallsOf[*sts.AssumeRoleInput](m))
+
+	m.ResetCounts()
+	require.Empty(t, m.Requests())
+}
+
+func TestRequestJournal_Concurrent(t *testing.T) {
+	listMock := awsmocker.NewSimpleMockedEndpoint("ecs", "ListClusters", map[string]any{})
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(listMock))
+	client := ecs.NewFromConfig(m.Config())
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				_, err := client.ListClusters(context.TODO(), &ecs.ListClustersInput{})
+				require.NoError(t, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				for _, rr := range m.Requests() {
+					_ = rr.MatchedEndpoint
+					_ = rr.StatusCode
+				}
+				_ = awsmocker.CallsOf[*ecs.ListClustersInput](m)
+			}
+		}()
+	}
+	wg.Wait()
+
+	requests := m.Requests()
+	require.Len(t, requests, 50)
+	for _, rr := range requests {
+		require.Same(t, listMock, rr.MatchedEndpoint)
+		require.Equal(t, http.StatusOK, rr.StatusCode)
+	}
+}
@@ -0,0 +1,142 @@
+package awsmocker
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// keys that can be used in a JMESPath expression without quoting
+var jmesIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// where a JSON document stopped containing the expected one
+type jsonContainsDiff struct {
+	path     string
+	expected any
+	actual   any
+	missing  bool
+}
+
+func (d *jsonContainsDiff) describe() (string, string) {
+	expected := fmt.Sprintf("%s == %s", d.path, EncodeAsJson(d.expected))
+	if d.missing {
+		return expected, "<absent>"
+	}
+	return expected, EncodeAsJson(d.actual)
+}
+
+// Checks that actual contains everything in expected. Both must already be normalized JSON values.
+// Returns nil if it does, or where the first difference was found.
+func jsonContains(expected, actual any, mode ArrayMatchMode, path string) *jsonContainsDiff {
+	switch exp := expected.(type) {
+	case map[string]any:
+		act, ok := actual.(map[string]any)
+		if !ok {
+			return &jsonContainsDiff{path: jsonRootPath(path), expected: expected, actual: actual}
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(exp)) {
+			keyPath := jsonKeyPath(path, k)
+
+			actValue, ok := act[k]
+			if !ok {
+				return &jsonContainsDiff{path: keyPath, expected: exp[k], missing: true}
+			}
+
+			if diff := jsonContains(exp[k], actValue, mode, keyPath); diff != nil {
+				return diff
+			}
+		}
+		return nil
+
+	case []any:
+		act, ok := actual.([]any)
+		if !ok {
+			return &jsonContainsDiff{path: jsonRootPath(path), expected: expected, actual: actual}
+		}
+
+		if mode == ArrayMatchAsSet {
+			return jsonContainsSet(exp, act, mode, path)
+		}
+
+		if len(exp) != len(act) {
+			return &jsonContainsDiff{path: jsonRootPath(path), expected: expected, actual: actual}
+		}
+
+		for i := range exp {
+			if diff := jsonContains(exp[i], act[i], mode, fmt.Sprintf("%s[%d]", path, i)); diff != nil {
+				return diff
+			}
+		}
+		return nil
+
+	default:
+		if !reflect.DeepEqual(expected, actual) {
+			return &jsonContainsDiff{path: jsonRootPath(path), expected: expected, actual: actual}
+		}
+		return nil
+	}
+}
+
+// each expected element must be contained by a different actual element
+func jsonContainsSet(expected, actual []any, mode ArrayMatchMode, path string) *jsonContainsDiff {
+	// the actual elements that each expected element could be matched with
+	candidates := make([][]int, len(expected))
+	for i, exp := range expected {
+		for j, act := range actual {
+			if jsonContains(exp, act, mode, "") == nil {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	// each actual element can only be used once, so this finds a matching for every expected element,
+	// moving earlier elements to another candidate when needed (augmenting paths)
+	matchedBy := make([]int, len(actual))
+	for j := range matchedBy {
+		matchedBy[j] = -1
+	}
+
+	var assign func(i int, seen []bool) bool
+	assign = func(i int, seen []bool) bool {
+		for _, j := range candidates[i] {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			if matchedBy[j] == -1 || assign(matchedBy[j], seen) {
+				matchedBy[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, exp := range expected {
+		if !assign(i, make([]bool, len(actual))) {
+			return &jsonContainsDiff{path: fmt.Sprintf("%s[%d]", path, i), expected: exp, actual: actual}
+		}
+	}
+
+	return nil
+}
+
+func jsonKeyPath(path, key string) string {
+	if !jmesIdentifierRegexp.MatchString(key) {
+		key = strconv.Quote(key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonRootPath(path string) string {
+	if path == "" {
+		return "@"
+	}
+	return path
+}
@@ -0,0 +1,115 @@
+package awsmocker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONContains(t *testing.T) {
+	actual := mustNormalizeJSON(`{
+		"Name": "rule",
+		"Limit": 5,
+		"Tags": [{"Key": "Env", "Value": "prod"}, {"Key": "Team", "Value": "a"}],
+		"Ids": ["a", "b", "c"],
+		"weird-key": {"x": 1}
+	}`)
+
+	tables := []struct {
+		name     string
+		expected any
+		mode     ArrayMatchMode
+		diff     *jsonContainsDiff
+	}{
+		{name: "Empty", expected: map[string]any{}},
+		{name: "Subset", expected: map[string]any{"Name": "rule"}},
+		{name: "IntVsFloat", expected: map[string]any{"Limit": 5}},
+		{name: "Nested", expected: map[string]any{"Tags": []any{map[string]any{"Key": "Env"}, map[string]any{"Value": "a"}}}},
+		{name: "Struct", expected: struct {
+			Name  string `json:"Name"`
+			Limit int    `json:"Limit"`
+		}{"rule", 5}},
+		{
+			name:     "Missing",
+			expected: map[string]any{"Description": "x"},
+			diff:     &jsonContainsDiff{path: "Description", expected: "x", missing: true},
+		},
+		{
+			name:     "WrongValue",
+			expected: map[string]any{"Tags": []any{map[string]any{}, map[string]any{"Key": "Owner"}}},
+			diff:     &jsonContainsDiff{path: "Tags[1].Key", expected: "Owner", actual: "Team"},
+		},
+		{
+			name:     "QuotedKey",
+			expected: map[string]any{"weird-key": map[string]any{"x": 2}},
+			diff:     &jsonContainsDiff{path: `"weird-key".x`, expected: float64(2), actual: float64(1)},
+		},
+		{
+			name:     "InOrderLength",
+			expected: map[string]any{"Ids": []string{"a", "b"}},
+			diff:     &jsonContainsDiff{path: "Ids", expected: []any{"a", "b"}, actual: []any{"a", "b", "c"}},
+		},
+		{
+			name:     "InOrderOrder",
+			expected: map[string]any{"Ids": []string{"c", "b", "a"}},
+			diff:     &jsonContainsDiff{path: "Ids[0]", expected: "c", actual: "a"},
+		},
+		{name: "SetSubset", expected: map[string]any{"Ids": []string{"c", "a"}}, mode: ArrayMatchAsSet},
+		{
+			name:     "SetDuplicates",
+			expected: map[string]any{"Ids": []string{"a", "a"}},
+			mode:     ArrayMatchAsSet,
+			diff:     &jsonContainsDiff{path: "Ids[1]", expected: "a", actual: []any{"a", "b", "c"}},
+		},
+		{
+			name:     "WrongType",
+			expected: map[string]any{"Name": map[string]any{"x": 1}},
+			diff:     &jsonContainsDiff{path: "Name", expected: map[string]any{"x": float64(1)}, actual: "rule"},
+		},
+	}
+
+	for _, table := range tables {
+		diff := jsonContains(mustNormalizeJSON(table.expected), actual, table.mode, "")
+		require.Equal(t, table.diff, diff, table.name)
+	}
+}
+
+func TestJSONContainsSet(t *testing.T) {
+	// the first expected element must not take the only actual element that satisfies the second
+	expected := mustNormalizeJSON(`[{"Source":"a"},{"Source":"a","DetailType":"x"}]`)
+	actual := mustNormalizeJSON(`[{"Source":"a","DetailType":"x"},{"Source":"a"}]`)
+	require.Nil(t, jsonContains(expected, actual, ArrayMatchAsSet, ""))
+
+	actual = mustNormalizeJSON(`[{"Source":"a","DetailType":"x"},{"Source":"b"}]`)
+	require.Equal(t, &jsonContainsDiff{
+		path:     "[1]",
+		expected: map[string]any{"Source": "a", "DetailType": "x"},
+		actual:   actual,
+	}, jsonContains(expected, actual, ArrayMatchAsSet, ""))
+}
+
+func TestMockedRequest_JSONContains(t *testing.T) {
+	mr := &MockedRequest{
+		JSONContains: map[string]any{
+			"Tags": []any{map[string]any{"Key": "Team"}},
+		},
+		JSONContainsArrays: ArrayMatchAsSet,
+	}
+
+	rr := &ReceivedRequest{
+		RawBody:     []byte(`{"Name":"x","Tags":[{"Key":"Env"},{"Key":"Team"}]}`),
+		JsonPayload: mustNormalizeJSON(`{"Name":"x","Tags":[{"Key":"Env"},{"Key":"Team"}]}`),
+	}
+	require.True(t, mr.matchRequest(rr))
+	require.Contains(t, mr.Inspect(), `JSONContains(sets)={"Tags":[{"Key":"Team"}]}`)
+
+	mr.JSONContainsArrays = ArrayMatchInOrder
+	require.False(t, mr.matchRequest(rr))
+	require.Equal(t, []matchCheck{{
+		field:    "JSONContains",
+		expected: `Tags == [{"Key":"Team"}]`,
+		actual:   `[{"Key":"Env"},{"Key":"Team"}]`,
+	}}, mr.checkRequest(rr, false).checks)
+
+	require.False(t, mr.matchRequest(&ReceivedRequest{}))
+}
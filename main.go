@@ -1,5 +1,7 @@
 package awsmocker
 
+import "slices"
+
 // Start the mocker
 func Start(t TestingT, optFns ...MockerOptionFunc) MockerInfo {
 
@@ -20,7 +22,8 @@ func Start(t TestingT, optFns ...MockerOptionFunc) MockerInfo {
 
 	mocks := make([]*MockedEndpoint, 0, len(options.Mocks))
 	for i := range options.Mocks {
-		if options.Mocks[i] == nil {
+		// skip nils, and mocks that were provided more than once
+		if options.Mocks[i] == nil || slices.Contains(mocks, options.Mocks[i]) {
 			continue
 		}
 		mocks = append(mocks, options.Mocks[i])
@@ -35,6 +38,8 @@ func Start(t TestingT, optFns ...MockerOptionFunc) MockerInfo {
 		doNotFailUnhandled: options.DoNotFailUnhandledRequests,
 		noMiddleware:       options.noMiddleware,
 		mocks:              mocks,
+		orderedMocks:       options.OrderedMocks,
+		unusedMocks:        options.unusedMocks,
 		// usingAwsConfig:     true,
 	}
 	server.Start()
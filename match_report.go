@@ -0,0 +1,159 @@
+package awsmocker
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// how many of the closest mocks to list when a request is not matched
+const nearMissLimit = 3
+
+// The result of checking a single constraint of a mock against a request
+type matchCheck struct {
+	field    string
+	passed   bool
+	skipped  bool
+	expected string
+	actual   string
+}
+
+// collects the result of checking each constraint of a mock
+type requestChecks struct {
+	failFast bool
+	failed   bool
+	checks   []matchCheck
+}
+
+// records the result of a check. Returns false if checking should stop
+func (c *requestChecks) add(field string, passed bool, expected, actual string) bool {
+	c.checks = append(c.checks, matchCheck{
+		field:    field,
+		passed:   passed,
+		expected: expected,
+		actual:   actual,
+	})
+
+	if !passed {
+		c.failed = true
+	}
+
+	return passed || !c.failFast
+}
+
+// records the result of checking a value against a ValueMatcher
+func (c *requestChecks) addValueMatch(field string, vm ValueMatcher, value string) bool {
+	return c.add(field, vm.MatchString(value), inspectValueMatcher(vm), strconv.Quote(value))
+}
+
+// adds the checks of a nested request, with their fields prefixed. Returns false if checking should stop
+func (c *requestChecks) merge(prefix string, child *requestChecks) bool {
+	for _, chk := range child.checks {
+		chk.field = prefix + chk.field
+		c.checks = append(c.checks, chk)
+	}
+
+	if child.failed {
+		c.failed = true
+	}
+
+	return !child.failed || !c.failFast
+}
+
+// describes the first check that failed
+func (c *requestChecks) firstFailure() string {
+	for _, chk := range c.checks {
+		if !chk.passed && !chk.skipped {
+			return fmt.Sprintf("%s: expected %s, got %s", chk.field, chk.expected, chk.actual)
+		}
+	}
+	return "<none>"
+}
+
+// records a check that was not run because an earlier one failed
+func (c *requestChecks) skip(field string) {
+	c.checks = append(c.checks, matchCheck{
+		field:   field,
+		skipped: true,
+	})
+}
+
+func (c *requestChecks) counts() (int, int) {
+	passed, failed := 0, 0
+	for _, chk := range c.checks {
+		switch {
+		case chk.passed:
+			passed++
+		case !chk.skipped:
+			failed++
+		}
+	}
+	return passed, failed
+}
+
+// Describes the mocks that came closest to matching the request, and which of their
+// constraints passed or failed. Returns an empty string if no mock came close.
+func (m *mocker) nearMissReport(rr *ReceivedRequest) string {
+	type candidate struct {
+		endpoint *MockedEndpoint
+		checks   *requestChecks
+		passed   int
+		failed   int
+	}
+
+	mocks := m.activeMocks()
+
+	candidates := make([]candidate, 0, len(mocks))
+	for _, mock := range mocks {
+		checks := mock.checkRequest(rr, false)
+		passed, failed := checks.counts()
+
+		// nothing in common with this request, so it is not worth mentioning
+		if passed == 0 {
+			continue
+		}
+
+		candidates = append(candidates, candidate{
+			endpoint: mock,
+			checks:   checks,
+			passed:   passed,
+			failed:   failed,
+		})
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.failed != b.failed {
+			return a.failed - b.failed
+		}
+		return b.passed - a.passed
+	})
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "Closest mocks:")
+
+	for i, cand := range candidates[:min(len(candidates), nearMissLimit)] {
+		fmt.Fprintf(buf, "  %d) %s\n", i+1, strings.ReplaceAll(cand.endpoint.Request.Inspect(), "\n", "\n     "))
+
+		for _, chk := range cand.checks.checks {
+			switch {
+			case chk.skipped:
+				fmt.Fprintf(buf, "       SKIP %s (not run, other checks failed)\n", chk.field)
+			case chk.passed:
+				fmt.Fprintf(buf, "       PASS %s: %s\n", chk.field, chk.expected)
+			default:
+				fmt.Fprintf(buf, "       FAIL %s\n", chk.field)
+				fmt.Fprintf(buf, "            - expected: %s\n", chk.expected)
+				fmt.Fprintf(buf, "            + actual:   %s\n", chk.actual)
+			}
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,64 @@
+package awsmocker
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMocker_nearMissReport(t *testing.T) {
+	m := &mocker{
+		scenarios: newScenarioStore(),
+		mocks: []*MockedEndpoint{
+			{
+				Request: &MockedRequest{
+					Hostname: "example.com",
+					Path:     "/other",
+				},
+			},
+			{
+				Request: &MockedRequest{
+					Hostname: "example.com",
+					Path:     "/test",
+					Params: url.Values{
+						"Name": []string{"expected"},
+					},
+					Matcher: func(*ReceivedRequest) bool { return true },
+				},
+			},
+			{
+				Request: &MockedRequest{
+					Service: "sts",
+				},
+			},
+		},
+	}
+
+	rr := &ReceivedRequest{
+		Hostname: "example.com",
+		Path:     "/test",
+		HttpRequest: &http.Request{
+			Method: http.MethodPost,
+			Form: url.Values{
+				"Name": []string{"actual"},
+			},
+		},
+	}
+
+	report := m.nearMissReport(rr)
+
+	require.Contains(t, report, "Closest mocks:")
+	require.Contains(t, report, "1) MReq<Hostname=example.com Path=/test Params=Name=expected>")
+	require.Contains(t, report, "2) MReq<Hostname=example.com Path=/other>")
+	require.NotContains(t, report, "Service=sts")
+
+	require.Contains(t, report, `PASS Hostname: "example.com"`)
+	require.Contains(t, report, `PASS Path: "/test"`)
+	require.Contains(t, report, "FAIL Params[Name]\n            - expected: \"expected\"\n            + actual:   \"actual\"")
+	require.Contains(t, report, "SKIP Matcher")
+	require.Contains(t, report, "FAIL Path\n            - expected: \"/other\"\n            + actual:   \"/test\"")
+
+	require.Empty(t, m.nearMissReport(&ReceivedRequest{Hostname: "nothing.local"}))
+}
@@ -2,6 +2,7 @@ package awsmocker
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -50,24 +51,6 @@ func TestEC2Middleware(t *testing.T) {
 				Service: "ec2",
 				Action:  "DescribeSubnets",
 			},
-			// Response: &MockedResponse{
-			// 	DoNotWrap: true,
-			// 	Body: map[string]any{
-			// 		"requestId": "43e9cb52-0e10-40fe-b457-988c8fbfea26",
-			// 		"subnetSet": map[string]any{
-			// 			"item": []any{
-			// 				map[string]any{
-			// 					"subnetId": "subnet-633333333333",
-			// 					"vpcId":    "vpc-123456789",
-			// 				},
-			// 				map[string]any{
-			// 					"subnetId": "subnet-644444444444",
-			// 					"vpcId":    "vpc-123456789",
-			// 				},
-			// 			},
-			// 		},
-			// 	},
-			// },
 			Response: &MockedResponse{
 				Body: &ec2.DescribeSubnetsOutput{
 					Subnets: []ec2Types.Subnet{
@@ -88,3 +71,33 @@ func TestEC2Middleware(t *testing.T) {
 	require.GreaterOrEqual(t, len(resp.Subnets), 1)
 	require.Equal(t, "vpc-123456789", *resp.Subnets[0].VpcId)
 }
+
+func TestWithoutMiddlewareMapBody(t *testing.T) {
+	m := Start(t, WithoutDefaultMocks(), WithoutMiddleware(), WithMocks(&MockedEndpoint{
+		Request: &MockedRequest{
+			Service: "ecs",
+			Action:  "DescribeServices",
+		},
+		Response: &MockedResponse{
+			Body: map[string]any{
+				"services": []map[string]any{
+					{"serviceName": "someservice"},
+				},
+			},
+		},
+	}))
+
+	// SDK requests always have headers, but none from the mocker middleware
+	resp, err := ecs.NewFromConfig(m.Config()).DescribeServices(context.TODO(), &ecs.DescribeServicesInput{
+		Services: []string{"someservice"},
+		Cluster:  aws.String("testcluster"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "someservice", *resp.Services[0].ServiceName)
+
+	requests := m.Requests()
+	require.Len(t, requests, 1)
+	require.NotEmpty(t, requests[0].HttpRequest.Header)
+	require.Empty(t, requests[0].HttpRequest.Header.Get(mwHeaderRequestId))
+	require.Equal(t, http.StatusOK, requests[0].StatusCode)
+}
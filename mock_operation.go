@@ -0,0 +1,94 @@
+package awsmocker
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Creates a mock for a single SDK operation, using the typed input and output of that operation.
+//
+//	awsmocker.MockOperation(func(ctx context.Context, in *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+//		return &ecs.DescribeServicesOutput{}, nil
+//	})
+//
+// The operation is identified by the input type itself, so the Service and Action do not need to be provided.
+// The mock does not have a Service, as the signing name of a service often differs from its SDK package name.
+// This panics if the types are not an SDK ACTIONInput/ACTIONOutput pair.
+//
+// This relies on the mocker middleware, so using it with [WithoutMiddleware] is reported as an error by [Start].
+func MockOperation[I, O any](fn func(context.Context, *I) (*O, error)) *MockedEndpoint {
+	action, err := operationNameFromTypes(reflect.TypeFor[I](), reflect.TypeFor[O]())
+	if err != nil {
+		panic(err)
+	}
+
+	return &MockedEndpoint{
+		Request: &MockedRequest{
+			Action: action,
+			InputMatcher: func(*I) bool {
+				return true
+			},
+		},
+		Response: &MockedResponse{
+			Body: func(rr *ReceivedRequest) (any, error) {
+				params, _ := rr.sdkParameters()
+				input, _ := params.(*I)
+				return fn(rr.HttpRequest.Context(), input)
+			},
+		},
+	}
+}
+
+// determines the operation name from the SDK input/output types
+func operationNameFromTypes(inType, outType reflect.Type) (string, error) {
+	if inType.Kind() != reflect.Struct || outType.Kind() != reflect.Struct {
+		return "", fmt.Errorf("operation types must be structs, got %s and %s", inType, outType)
+	}
+
+	action, ok := strings.CutSuffix(inType.Name(), "Input")
+	if !ok || action == "" {
+		return "", fmt.Errorf("input type %s is not an SDK ACTIONInput type", inType)
+	}
+
+	if outType.Name() != action+"Output" || outType.PkgPath() != inType.PkgPath() {
+		return "", fmt.Errorf("output type %s does not belong to the same operation as %s", outType, inType)
+	}
+
+	return action, nil
+}
+
+// Validates the signature of typed body functions (the ones that return an error)
+// so mistakes are reported when the mock is registered instead of being ignored at request time.
+func validateBodyFunc(typ reflect.Type) error {
+	if typ.Kind() != reflect.Func || typ.NumOut() == 0 || typ.Out(typ.NumOut()-1) != errType {
+		// not a typed function, these are checked when they are called
+		return nil
+	}
+
+	isInputType := func(t reflect.Type) bool {
+		return t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct && t != rrType
+	}
+
+	errBadSig := fmt.Errorf("body function %s must be one of func(*ReceivedRequest) (*service.ACTIONOutput, error), func(*ReceivedRequest, *service.ACTIONInput) (*service.ACTIONOutput, error) or func(*service.ACTIONInput) (*service.ACTIONOutput, error)", typ)
+
+	if typ.NumOut() != 2 {
+		return errBadSig
+	}
+
+	switch typ.NumIn() {
+	case 1:
+		if typ.In(0) != rrType && !isInputType(typ.In(0)) {
+			return errBadSig
+		}
+	case 2:
+		if typ.In(0) != rrType || !isInputType(typ.In(1)) {
+			return errBadSig
+		}
+	default:
+		return errBadSig
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package awsmocker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationNameFromTypes(t *testing.T) {
+	action, err := operationNameFromTypes(reflect.TypeFor[sts.GetCallerIdentityInput](), reflect.TypeFor[sts.GetCallerIdentityOutput]())
+	require.NoError(t, err)
+	require.Equal(t, "GetCallerIdentity", action)
+
+	_, err = operationNameFromTypes(reflect.TypeFor[sts.GetCallerIdentityInput](), reflect.TypeFor[ecs.GetTaskProtectionOutput]())
+	require.ErrorContains(t, err, "does not belong to the same operation")
+
+	_, err = operationNameFromTypes(reflect.TypeFor[sts.GetCallerIdentityOutput](), reflect.TypeFor[sts.GetCallerIdentityOutput]())
+	require.ErrorContains(t, err, "is not an SDK ACTIONInput type")
+
+	_, err = operationNameFromTypes(reflect.TypeFor[*sts.GetCallerIdentityInput](), reflect.TypeFor[sts.GetCallerIdentityOutput]())
+	require.ErrorContains(t, err, "must be structs")
+}
+
+func TestValidateBodyFunc(t *testing.T) {
+	valid := []any{
+		"not a func",
+		func(*ReceivedRequest) string { return "" },
+		func(*ReceivedRequest) (string, int, string, string) { return "", 0, "", "" },
+		func(*ReceivedRequest) (any, error) { return nil, nil },
+		func(*ReceivedRequest) (*sts.GetCallerIdentityOutput, error) { return nil, nil },
+		func(*ReceivedRequest, *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+			return nil, nil
+		},
+		func(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) { return nil, nil },
+	}
+
+	for _, fn := range valid {
+		require.NoErrorf(t, validateBodyFunc(reflect.TypeOf(fn)), "expected %T to be valid", fn)
+	}
+
+	invalid := []any{
+		func() error { return nil },
+		func(*sts.GetCallerIdentityInput) error { return nil },
+		func(sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) { return nil, nil },
+		func(*sts.GetCallerIdentityInput, *ReceivedRequest) (*sts.GetCallerIdentityOutput, error) {
+			return nil, nil
+		},
+		func(*ReceivedRequest, *sts.GetCallerIdentityInput, string) (*sts.GetCallerIdentityOutput, error) {
+			return nil, nil
+		},
+		func() (*sts.GetCallerIdentityOutput, error) { return nil, nil },
+	}
+
+	for _, fn := range invalid {
+		require.Errorf(t, validateBodyFunc(reflect.TypeOf(fn)), "expected %T to be invalid", fn)
+	}
+}
@@ -0,0 +1,114 @@
+package awsmocker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestMockOperation(t *testing.T) {
+	type ctxKey struct{}
+
+	mock := awsmocker.MockOperation(func(ctx context.Context, in *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+		if aws.ToString(in.Cluster) == "broken" {
+			return nil, errors.New("cluster is broken")
+		}
+
+		return &ecs.DescribeServicesOutput{
+			Services: []ecsTypes.Service{
+				{
+					ServiceName: aws.String(in.Services[0]),
+					ClusterArn:  aws.String(aws.ToString(in.Cluster) + ctx.Value(ctxKey{}).(string)),
+				},
+			},
+		}, nil
+	})
+
+	require.Empty(t, mock.Request.Service)
+	require.Equal(t, "DescribeServices", mock.Request.Action)
+	require.Contains(t, mock.Request.Inspect(), "Action=DescribeServices")
+	require.Contains(t, mock.Request.Inspect(), "*ecs.DescribeServicesInput")
+
+	m := awsmocker.Start(t, awsmocker.WithMocks(mock))
+
+	client := ecs.NewFromConfig(m.Config())
+	ctx := context.WithValue(context.Background(), ctxKey{}, "-fromctx")
+
+	resp, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Services: []string{"someservice"},
+		Cluster:  aws.String("testcluster"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "someservice", *resp.Services[0].ServiceName)
+	require.Equal(t, "testcluster-fromctx", *resp.Services[0].ClusterArn)
+
+	_, err = client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Services: []string{"someservice"},
+		Cluster:  aws.String("broken"),
+	})
+	require.ErrorContains(t, err, "cluster is broken")
+
+	require.Panics(t, func() {
+		awsmocker.MockOperation(func(context.Context, *ecs.DescribeServicesInput) (*ecs.ListServicesOutput, error) {
+			return nil, nil
+		})
+	})
+
+	require.Panics(t, func() {
+		awsmocker.MockOperation(func(context.Context, *struct{}) (*ecs.ListServicesOutput, error) {
+			return nil, nil
+		})
+	})
+}
+
+func TestInvalidBodyFuncReportedAtStart(t *testing.T) {
+	tm := NewTestingMock(t)
+
+	awsmocker.Start(tm, awsmocker.WithMocks(&awsmocker.MockedEndpoint{
+		Request: &awsmocker.MockedRequest{
+			Service: "sts",
+			Action:  "GetCallerIdentity",
+		},
+		Response: &awsmocker.MockedResponse{
+			Body: func(_ *sts.GetCallerIdentityInput, _ string) (*sts.GetCallerIdentityOutput, error) {
+				return nil, nil
+			},
+		},
+	}))
+
+	require.True(t, tm.errored)
+	require.Contains(t, tm.errorMessages[0], "Invalid mock MReq<Service=sts Action=GetCallerIdentity>")
+}
+
+func TestMockOperationSigningName(t *testing.T) {
+	mock := awsmocker.MockOperation(func(_ context.Context, in *eventbridge.PutRuleInput) (*eventbridge.PutRuleOutput, error) {
+		return &eventbridge.PutRuleOutput{RuleArn: aws.String("arn:" + aws.ToString(in.Name))}, nil
+	})
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(mock))
+
+	resp, err := eventbridge.NewFromConfig(m.Config()).PutRule(context.TODO(), &eventbridge.PutRuleInput{Name: aws.String("rule")})
+	require.NoError(t, err)
+	require.Equal(t, "arn:rule", *resp.RuleArn)
+}
+
+func TestMockOperationWithoutMiddleware(t *testing.T) {
+	tm := NewTestingMock(t)
+
+	awsmocker.Start(tm, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutMiddleware(), awsmocker.WithMocks(
+		awsmocker.MockOperation(func(context.Context, *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+			return &sts.GetCallerIdentityOutput{}, nil
+		}),
+	))
+
+	require.True(t, tm.errored)
+	require.Contains(t, tm.errorMessages[0], "Invalid mock MReq<Action=GetCallerIdentity")
+	require.Contains(t, tm.errorMessages[0], "InputMatcher needs the mocker middleware")
+}
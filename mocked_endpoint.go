@@ -1,24 +1,162 @@
 package awsmocker
 
+import (
+	"net/http"
+	"strconv"
+	"sync"
+)
+
 type MockedEndpoint struct {
 	Request  *MockedRequest
 	Response *MockedResponse
+
+	// A sequence of responses that will be returned for successive matches of this endpoint.
+	// If this is provided, then Response is ignored.
+	Responses []*MockedResponse
+
+	// What to do once every response in Responses has been returned. Default is to repeat the last one.
+	SequenceMode ResponseSequenceMode
+
+	// Name of the scenario this endpoint takes part in. Scenario state is shared by every mock in the mocker.
+	Scenario string
+
+	// Only match when the scenario is in this state. Empty means any state.
+	// Every scenario begins in the [ScenarioStateStarted] state.
+	RequiredState string
+
+	// Move the scenario to this state once this endpoint has matched a request. Empty means no change.
+	NewState string
+
+	// When multiple mocks match a request, the one with the highest priority wins.
+	// Mocks with the same priority are ranked by how specific their request is,
+	// and then by the order they were provided in.
+	//
+	// Default is 0. Negative values are allowed.
+	Priority int
+
+	// Optional mocks are not reported when they are never used.
+	// See [WithFailOnUnusedMocks]
+	Optional bool
+
+	// how many times this endpoint is expected to be matched, if set
+	expectedCalls *callExpectation
+
+	// number of responses that have been taken from the sequence
+	sequenceCount int
+	mu            sync.Mutex
 }
 
 func (m *MockedEndpoint) prep() {
-	m.Response.action = m.Request.Action
+	if m.Response != nil {
+		m.Response.action = m.Request.Action
+		m.Response.prep()
+	}
+
+	for _, resp := range m.Responses {
+		resp.action = m.Request.Action
+		resp.prep()
+	}
 
-	m.Response.prep()
 	m.Request.prep()
 
 }
 
+// Returns an error if this endpoint is configured in a way that can never work
+func (m *MockedEndpoint) validate() error {
+	if err := m.Request.validate(); err != nil {
+		return err
+	}
+
+	if m.Response != nil {
+		if err := m.Response.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, resp := range m.Responses {
+		if err := resp.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *MockedEndpoint) matchRequest(rr *ReceivedRequest) bool {
-	return m.Request.matchRequest(rr)
+	return !m.checkRequest(rr, true).failed
+}
+
+func (m *MockedEndpoint) checkRequest(rr *ReceivedRequest, failFast bool) *requestChecks {
+	c := &requestChecks{failFast: failFast}
+
+	if m.Scenario != "" && m.RequiredState != "" {
+		state := ScenarioStateStarted
+		if rr.mocker != nil {
+			state = rr.mocker.scenarios.get(m.Scenario)
+		}
+
+		if !c.add("Scenario["+m.Scenario+"]", state == m.RequiredState, strconv.Quote(m.RequiredState), strconv.Quote(state)) {
+			return c
+		}
+	}
+
+	m.Request.addChecks(c, rr)
+	return c
+}
+
+// mocks that require a scenario state are more specific than ones that do not
+func (m *MockedEndpoint) specificity() int {
+	score := m.Request.specificity()
+	if m.Scenario != "" && m.RequiredState != "" {
+		score++
+	}
+	return score
 }
 
 func (m *MockedEndpoint) getResponse(rr *ReceivedRequest) *httpResponse {
-	return m.Response.getResponse(rr)
+	if len(m.Responses) == 0 {
+		return m.Response.getResponse(rr)
+	}
+
+	resp := m.nextResponse()
+	if resp == nil {
+		if rr.mocker != nil {
+			rr.mocker.t.Errorf("Mock %s was called more times than it has responses (%d)", m.Request.Inspect(), len(m.Responses))
+		}
+		return generateErrorStruct(http.StatusNotImplemented, "SequenceExhausted", "All %d responses for this mock have already been returned", len(m.Responses)).getResponse(rr)
+	}
+
+	return resp.getResponse(rr)
+}
+
+func (m *MockedEndpoint) resetCounts() {
+	m.Request.resetMatchCount()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sequenceCount = 0
+}
+
+// returns the next response in the sequence, or nil if the sequence is exhausted
+func (m *MockedEndpoint) nextResponse() *MockedResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx := m.sequenceCount
+	m.sequenceCount++
+
+	if idx < len(m.Responses) {
+		return m.Responses[idx]
+	}
+
+	switch m.SequenceMode {
+	case ResponseSequenceCycle:
+		return m.Responses[idx%len(m.Responses)]
+	case ResponseSequenceFail:
+		return nil
+	default:
+		return m.Responses[len(m.Responses)-1]
+	}
 }
 
 // Generates a simple [MockedEndpoint] for the Service:Action
@@ -1,8 +1,11 @@
 package awsmocker_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/stretchr/testify/require"
 	"github.com/webdestroya/awsmocker"
 )
@@ -17,3 +20,66 @@ func TestNewSimpleMockedEndpoint(t *testing.T) {
 	require.Equal(t, "GetCallerIdentity", me.Request.Action)
 	require.Equal(t, "SimpleBody", me.Response.Body)
 }
+
+func TestMockedEndpoint_Responses(t *testing.T) {
+	statusResponse := func(status string) *awsmocker.MockedResponse {
+		return &awsmocker.MockedResponse{
+			Body: map[string]any{
+				"services": []map[string]any{
+					{
+						"serviceName": "someservice",
+						"status":      status,
+					},
+				},
+			},
+		}
+	}
+
+	tables := []struct {
+		name     string
+		mode     awsmocker.ResponseSequenceMode
+		expected []string
+	}{
+		{"RepeatLast", awsmocker.ResponseSequenceRepeatLast, []string{"PENDING", "PENDING", "ACTIVE", "ACTIVE", "ACTIVE"}},
+		{"Cycle", awsmocker.ResponseSequenceCycle, []string{"PENDING", "PENDING", "ACTIVE", "PENDING", "PENDING"}},
+		{"Fail", awsmocker.ResponseSequenceFail, []string{"PENDING", "PENDING", "ACTIVE", "", ""}},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			tm := NewTestingMock(t)
+
+			m := awsmocker.Start(tm, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(&awsmocker.MockedEndpoint{
+				Request: &awsmocker.MockedRequest{
+					Service: "ecs",
+					Action:  "DescribeServices",
+				},
+				Responses: []*awsmocker.MockedResponse{
+					statusResponse("PENDING"),
+					statusResponse("PENDING"),
+					statusResponse("ACTIVE"),
+				},
+				SequenceMode: table.mode,
+			}))
+
+			client := ecs.NewFromConfig(m.Config())
+
+			for _, expected := range table.expected {
+				resp, err := client.DescribeServices(context.TODO(), &ecs.DescribeServicesInput{
+					Services: []string{"someservice"},
+					Cluster:  aws.String("testcluster"),
+				})
+
+				if expected == "" {
+					require.ErrorContains(t, err, "SequenceExhausted")
+					continue
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, expected, *resp.Services[0].Status)
+			}
+
+			require.Equal(t, table.mode == awsmocker.ResponseSequenceFail, tm.errored)
+		})
+	}
+}
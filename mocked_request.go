@@ -1,9 +1,13 @@
 package awsmocker
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -25,36 +29,101 @@ type MockedRequest struct {
 	// The hostname only. Does not include the port
 	Hostname string
 
+	// Match the hostname using a [ValueMatcher]
+	HostnameMatch ValueMatcher
+
 	// The AWS service shortcode
 	Service string
 
+	// Match the service using a [ValueMatcher]
+	ServiceMatch ValueMatcher
+
 	// The AWS API Action being performed
 	Action string
 
-	// Body to match against
+	// Match the action using a [ValueMatcher], such as Glob("Describe*") or OneOf("PutItem", "UpdateItem")
+	ActionMatch ValueMatcher
+
+	// The region the request was signed for
+	Region string
+
+	// Match the region using a [ValueMatcher], such as Prefix("eu-")
+	RegionMatch ValueMatcher
+
+	// The account the request was made from. See [MockerInfo.ConfigForAccount]
+	AccountId string
+
+	// Body to match against. This must match the raw request body exactly
 	Body string
 
+	// Match the raw request body, using a regex
+	BodyRegex *regexp.Regexp
+
+	// Match the request body as JSON. Key order and whitespace are ignored.
+	//
+	// This can be a string or []byte of JSON, or any value that can be encoded as JSON (map, struct, etc)
+	BodyJSON any
+
+	// Match when the JSON body contains this document. Keys that are not listed are ignored, so the
+	// request can have more fields than this. Numbers are compared by value, so 5 and 5.0 are equal.
+	//
+	// This can be a string or []byte of JSON, or any value that can be encoded as JSON (map, struct, etc)
+	JSONContains any
+
+	// How arrays in JSONContains are compared. Default is in order
+	JSONContainsArrays ArrayMatchMode
+
 	// Match against specific parameters in the request.
 	// This is only used for XML/Form requests (not the newer JSON ones)
 	Params url.Values
 
+	// Match parameters in the request using a [ValueMatcher]. The parameter must have a value that matches.
+	// Like Params, this is only used for XML/Form requests
+	ParamsMatch map[string]ValueMatcher
+
 	// Match a specific HTTP method
 	Method string
 
+	// Match the HTTP method using a [ValueMatcher]
+	MethodMatch ValueMatcher
+
+	// Match against the HTTP headers of the request. Header names are case insensitive.
+	//
+	// The value can be one of:
+	//   - string: the header must have this exact value
+	//   - []string: the header values must exactly equal this list
+	//   - bool: true requires the header to be present, false requires it to be absent
+	//   - *regexp.Regexp: the header must have a value matching the regex
+	//   - func(string) bool: the header must have a value for which the function returns true
+	Headers map[string]any
+
 	// Match the URL path
 	Path string
 
 	// Match the URL path, using a regex
 	PathRegex *regexp.Regexp
 
+	// Match the URL path using a [ValueMatcher]
+	PathMatch ValueMatcher
+
 	// Is this an instance metadata request?
 	// setting this to true will match against both the IPv4 and IPv6 hostnames
 	IsEc2IMDS bool
 
 	// Matches a JSON request body by resolving the jmespath expression as keys
 	// and comparing the values returned against the value provided in the map
+	//
+	// This also works for query and EC2 protocol requests, using the form params rebuilt
+	// into a nested structure, and for XML bodies. See [ReceivedRequest.FormPayload] and [ReceivedRequest.XmlPayload]
 	JMESPathMatches map[string]any
 
+	// Match against the typed SDK input for the request. This must be a function of the form
+	// func(*service.ACTIONInput) bool
+	//
+	// This only works when using the AWS config from the mocker, as the input is captured by its middleware.
+	// Requests for other operations will not match.
+	InputMatcher any
+
 	// Write a custom matcher function that will be used to match a request.
 	// this runs after checking the other fields, so you can use those as filters.
 	Matcher func(*ReceivedRequest) bool
@@ -64,23 +133,155 @@ type MockedRequest struct {
 	// 0 (default) means it will live forever
 	MaxMatchCount int
 
+	// set when this request was built with AnyOf, AllOf or Not
+	composition *requestComposition
+
+	// BodyJSON and JSONContains, normalized once by prep
+	bodyJSON     *normalizedJSON
+	jsonContains *normalizedJSON
+
 	// number of times this request has matched
 	matchCount int64
 	mu         sync.Mutex
 }
 
 func (mr *MockedRequest) prep() {
+	if mr.BodyJSON != nil {
+		mr.bodyJSON = newNormalizedJSON(mr.BodyJSON)
+	}
+
+	if mr.JSONContains != nil {
+		mr.jsonContains = newNormalizedJSON(mr.JSONContains)
+	}
+
+	if mr.InputMatcher != nil {
+		// this will panic for invalid functions, so you find out before any requests are made
+		_ = inputMatcherType(mr.InputMatcher)
+	}
+
+	if mr.composition != nil {
+		mr.composition.prep()
+	}
+}
+
+// Returns an error if this request is configured in a way that can never match
+func (m *MockedRequest) validate() error {
+	for _, k := range slices.Sorted(maps.Keys(m.Headers)) {
+		if err := validateHeaderValue(m.Headers[k]); err != nil {
+			return fmt.Errorf("Headers[%s]: %w", http.CanonicalHeaderKey(k), err)
+		}
+	}
 
+	if m.BodyJSON != nil {
+		if _, err := m.bodyJSONValue(); err != nil {
+			return fmt.Errorf("BodyJSON: %w", err)
+		}
+	}
+
+	if m.JSONContains != nil {
+		if _, err := m.jsonContainsValue(); err != nil {
+			return fmt.Errorf("JSONContains: %w", err)
+		}
+	}
+
+	if m.composition != nil {
+		return m.composition.validate()
+	}
+
+	return nil
 }
 
-func (m *MockedRequest) incMatchCount() {
+// Returns true if this request, or any request it is composed of, has an InputMatcher
+func (m *MockedRequest) usesInputMatcher() bool {
+	if m.InputMatcher != nil {
+		return true
+	}
+
+	if m.composition != nil {
+		for _, child := range m.composition.children {
+			if child.usesInputMatcher() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns how specific this request is, based on the number of constraints it has.
+// When multiple mocks match a request, the most specific one is used.
+func (m *MockedRequest) specificity() int {
+	score := len(m.Params) + len(m.ParamsMatch) + len(m.Headers) + len(m.JMESPathMatches)
+
+	for _, isSet := range []bool{
+		m.Strict,
+		m.Hostname != "",
+		m.HostnameMatch != nil,
+		m.Service != "",
+		m.ServiceMatch != nil,
+		m.Action != "",
+		m.ActionMatch != nil,
+		m.Region != "",
+		m.RegionMatch != nil,
+		m.AccountId != "",
+		m.Body != "",
+		m.BodyRegex != nil,
+		m.BodyJSON != nil,
+		m.JSONContains != nil,
+		m.Method != "",
+		m.MethodMatch != nil,
+		m.Path != "",
+		m.PathRegex != nil,
+		m.PathMatch != nil,
+		m.IsEc2IMDS,
+		m.InputMatcher != nil,
+		m.Matcher != nil,
+	} {
+		if isSet {
+			score++
+		}
+	}
+
+	if m.composition != nil {
+		score += m.composition.specificity()
+	}
+
+	return score
+}
+
+// Returns the number of times this request has been matched
+func (m *MockedRequest) MatchCount() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	return int(m.matchCount)
+}
+
+func (m *MockedRequest) resetMatchCount() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matchCount = 0
+}
+
+// Counts a match, unless the request has already been matched MaxMatchCount times.
+// The check and increment are done together, so concurrent requests cannot both take the last match.
+func (m *MockedRequest) tryIncMatchCount() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.MaxMatchCount > 0 && m.matchCount >= int64(m.MaxMatchCount) {
+		return false
+	}
+
 	m.matchCount += 1
+	return true
 }
 
 // Returns a string to help identify this MockedRequest
 func (m *MockedRequest) Inspect() string {
+	return m.inspect("")
+}
+
+func (m *MockedRequest) inspect(indent string) string {
 	parts := make([]string, 0, 10)
 
 	if m.Strict {
@@ -91,10 +292,30 @@ func (m *MockedRequest) Inspect() string {
 		parts = append(parts, fmt.Sprintf("Service=%s", m.Service))
 	}
 
+	if m.ServiceMatch != nil {
+		parts = append(parts, fmt.Sprintf("ServiceMatch=%s", inspectValueMatcher(m.ServiceMatch)))
+	}
+
 	if m.Action != "" {
 		parts = append(parts, fmt.Sprintf("Action=%s", m.Action))
 	}
 
+	if m.ActionMatch != nil {
+		parts = append(parts, fmt.Sprintf("ActionMatch=%s", inspectValueMatcher(m.ActionMatch)))
+	}
+
+	if m.Region != "" {
+		parts = append(parts, fmt.Sprintf("Region=%s", m.Region))
+	}
+
+	if m.RegionMatch != nil {
+		parts = append(parts, fmt.Sprintf("RegionMatch=%s", inspectValueMatcher(m.RegionMatch)))
+	}
+
+	if m.AccountId != "" {
+		parts = append(parts, fmt.Sprintf("AccountId=%s", m.AccountId))
+	}
+
 	if m.IsEc2IMDS {
 		parts = append(parts, fmt.Sprintf("imds=%t", m.IsEc2IMDS))
 	}
@@ -103,6 +324,10 @@ func (m *MockedRequest) Inspect() string {
 		parts = append(parts, fmt.Sprintf("Hostname=%s", m.Hostname))
 	}
 
+	if m.HostnameMatch != nil {
+		parts = append(parts, fmt.Sprintf("HostnameMatch=%s", inspectValueMatcher(m.HostnameMatch)))
+	}
+
 	if m.Path != "" {
 		parts = append(parts, fmt.Sprintf("Path=%s", m.Path))
 	}
@@ -111,124 +336,454 @@ func (m *MockedRequest) Inspect() string {
 		parts = append(parts, fmt.Sprintf("Method=%s", m.Method))
 	}
 
+	if m.MethodMatch != nil {
+		parts = append(parts, fmt.Sprintf("MethodMatch=%s", inspectValueMatcher(m.MethodMatch)))
+	}
+
 	if m.PathRegex != nil {
 		parts = append(parts, fmt.Sprintf("PathRegex=%s", m.PathRegex.String()))
 	}
 
+	if m.PathMatch != nil {
+		parts = append(parts, fmt.Sprintf("PathMatch=%s", inspectValueMatcher(m.PathMatch)))
+	}
+
 	if len(m.Params) > 0 {
 		parts = append(parts, fmt.Sprintf("Params=%s", m.Params.Encode()))
 	}
 
+	if len(m.ParamsMatch) > 0 {
+		paramParts := make([]string, 0, len(m.ParamsMatch))
+		for _, k := range slices.Sorted(maps.Keys(m.ParamsMatch)) {
+			paramParts = append(paramParts, k+"="+inspectValueMatcher(m.ParamsMatch[k]))
+		}
+		parts = append(parts, fmt.Sprintf("ParamsMatch=[%s]", strings.Join(paramParts, " ")))
+	}
+
+	if m.InputMatcher != nil {
+		parts = append(parts, fmt.Sprintf("InputMatcher=%T", m.InputMatcher))
+	}
+
+	if len(m.Headers) > 0 {
+		headerParts := make([]string, 0, len(m.Headers))
+		for _, k := range slices.Sorted(maps.Keys(m.Headers)) {
+			headerParts = append(headerParts, http.CanonicalHeaderKey(k)+"="+inspectHeaderValue(m.Headers[k]))
+		}
+		parts = append(parts, fmt.Sprintf("Headers=[%s]", strings.Join(headerParts, " ")))
+	}
+
 	if m.Body != "" {
 		parts = append(parts, fmt.Sprintf("Body=%s", m.Body))
 	}
 
+	if m.BodyRegex != nil {
+		parts = append(parts, fmt.Sprintf("BodyRegex=%s", m.BodyRegex.String()))
+	}
+
+	if m.BodyJSON != nil {
+		parts = append(parts, fmt.Sprintf("BodyJSON=%s", inspectNormalizedJSON(m.bodyJSONValue())))
+	}
+
+	if m.JSONContains != nil {
+		mode := ""
+		if m.JSONContainsArrays == ArrayMatchAsSet {
+			mode = "(sets)"
+		}
+		parts = append(parts, fmt.Sprintf("JSONContains%s=%s", mode, inspectNormalizedJSON(m.jsonContainsValue())))
+	}
+
+	if m.composition != nil {
+		parts = append(parts, m.composition.inspect(indent))
+	}
+
 	return "MReq<" + strings.Join(parts, " ") + ">"
 }
 
 func (m *MockedRequest) matchRequest(rr *ReceivedRequest) bool {
+	return !m.checkRequest(rr, true).failed
+}
 
-	if m.MaxMatchCount > 0 && m.matchCount >= int64(m.MaxMatchCount) {
-		return false
+// Checks each constraint of this request against the received request.
+//
+// With failFast, checking stops at the first failure. Otherwise every constraint is
+// checked so the results can be used to explain why a request did not match.
+func (m *MockedRequest) checkRequest(rr *ReceivedRequest, failFast bool) *requestChecks {
+	c := &requestChecks{failFast: failFast}
+	m.addChecks(c, rr)
+	return c
+}
+
+func (m *MockedRequest) addChecks(c *requestChecks, rr *ReceivedRequest) {
+
+	if m.MaxMatchCount > 0 {
+		count := m.MatchCount()
+		if !c.add("MaxMatchCount", count < m.MaxMatchCount, fmt.Sprintf("fewer than %d matches", m.MaxMatchCount), fmt.Sprintf("%d matches", count)) {
+			return
+		}
 	}
 
-	if !m.matchRequestLazy(rr) {
-		return false
+	if m.Hostname != "" && !c.add("Hostname", rr.Hostname == m.Hostname, strconv.Quote(m.Hostname), strconv.Quote(rr.Hostname)) {
+		return
 	}
 
-	if m.Strict {
-		return m.matchRequestStrict(rr)
+	if m.HostnameMatch != nil && !c.addValueMatch("HostnameMatch", m.HostnameMatch, rr.Hostname) {
+		return
 	}
 
-	return true
-}
+	if m.Service != "" && !c.add("Service", rr.Service == m.Service, strconv.Quote(m.Service), strconv.Quote(rr.Service)) {
+		return
+	}
 
-func (m *MockedRequest) matchRequestLazy(rr *ReceivedRequest) bool {
+	if m.ServiceMatch != nil && !c.addValueMatch("ServiceMatch", m.ServiceMatch, rr.Service) {
+		return
+	}
 
-	if m.Hostname != "" && rr.Hostname != m.Hostname {
-		return false
+	if m.Action != "" && !c.add("Action", rr.Action == m.Action, strconv.Quote(m.Action), strconv.Quote(rr.Action)) {
+		return
 	}
 
-	if m.Service != "" && rr.Service != m.Service {
-		return false
+	if m.ActionMatch != nil && !c.addValueMatch("ActionMatch", m.ActionMatch, rr.Action) {
+		return
 	}
 
-	if m.Action != "" && rr.Action != m.Action {
-		return false
+	if m.Region != "" && !c.add("Region", rr.Region == m.Region, strconv.Quote(m.Region), strconv.Quote(rr.Region)) {
+		return
 	}
 
-	if m.Path != "" && rr.Path != m.Path {
-		return false
+	if m.RegionMatch != nil && !c.addValueMatch("RegionMatch", m.RegionMatch, rr.Region) {
+		return
 	}
 
-	if m.Method != "" && rr.HttpRequest.Method != m.Method {
-		return false
+	if m.AccountId != "" && !c.add("AccountId", rr.AccountId == m.AccountId, strconv.Quote(m.AccountId), strconv.Quote(rr.AccountId)) {
+		return
 	}
 
-	if m.IsEc2IMDS && !(rr.Hostname == imdsHost4 || rr.Hostname == imdsHost6) {
-		return false
+	if m.Path != "" && !c.add("Path", rr.Path == m.Path, strconv.Quote(m.Path), strconv.Quote(rr.Path)) {
+		return
 	}
 
-	if m.PathRegex != nil && !m.PathRegex.MatchString(rr.Path) {
-		return false
+	if m.PathMatch != nil && !c.addValueMatch("PathMatch", m.PathMatch, rr.Path) {
+		return
+	}
+
+	if m.Method != "" || m.MethodMatch != nil {
+		method := ""
+		if rr.HttpRequest != nil {
+			method = rr.HttpRequest.Method
+		}
+		if m.Method != "" && !c.add("Method", method == m.Method, strconv.Quote(m.Method), strconv.Quote(method)) {
+			return
+		}
+		if m.MethodMatch != nil && !c.addValueMatch("MethodMatch", m.MethodMatch, method) {
+			return
+		}
+	}
+
+	if m.IsEc2IMDS && !c.add("IsEc2IMDS", rr.Hostname == imdsHost4 || rr.Hostname == imdsHost6, "an IMDS hostname", strconv.Quote(rr.Hostname)) {
+		return
+	}
+
+	if m.PathRegex != nil && !c.add("PathRegex", m.PathRegex.MatchString(rr.Path), "~"+m.PathRegex.String(), strconv.Quote(rr.Path)) {
+		return
+	}
+
+	if m.Body != "" && !c.add("Body", string(rr.RawBody) == m.Body, strconv.Quote(m.Body), strconv.Quote(string(rr.RawBody))) {
+		return
+	}
+
+	if m.BodyRegex != nil && !c.add("BodyRegex", m.BodyRegex.Match(rr.RawBody), "~"+m.BodyRegex.String(), strconv.Quote(string(rr.RawBody))) {
+		return
+	}
+
+	if m.BodyJSON != nil {
+		expected, err := m.bodyJSONValue()
+		if err != nil {
+			if !c.add("BodyJSON", false, "valid JSON in the mock", err.Error()) {
+				return
+			}
+		} else if !c.add("BodyJSON", matchBodyJSON(expected, rr), EncodeAsJson(expected), string(rr.RawBody)) {
+			return
+		}
+	}
+
+	if m.JSONContains != nil {
+		expected, err := m.jsonContainsValue()
+		if err != nil {
+			if !c.add("JSONContains", false, "valid JSON in the mock", err.Error()) {
+				return
+			}
+		} else if rr.JsonPayload == nil {
+			if !c.add("JSONContains", false, "a JSON payload", "<none>") {
+				return
+			}
+		} else if diff := jsonContains(expected, rr.JsonPayload, m.JSONContainsArrays, ""); diff != nil {
+			exp, act := diff.describe()
+			if !c.add("JSONContains", false, exp, act) {
+				return
+			}
+		} else {
+			c.add("JSONContains", true, EncodeAsJson(expected), string(rr.RawBody))
+		}
+	}
+
+	if len(m.Headers) > 0 {
+		var header http.Header
+		if rr.HttpRequest != nil {
+			header = rr.HttpRequest.Header
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(m.Headers)) {
+			values := header.Values(k)
+			if !c.add("Headers["+http.CanonicalHeaderKey(k)+"]", matchHeaderValue(values, m.Headers[k]), inspectExpectedHeader(m.Headers[k]), inspectValues(values)) {
+				return
+			}
+		}
 	}
 
 	if len(m.JMESPathMatches) > 0 {
-		if ret := m.matchJmespath(rr); !ret {
-			return false
+		// you provided Jmes matchers, but there is no structured payload, so it will never match
+		payload := rr.payload()
+		if payload == nil {
+			if !c.add("JMESPathMatches", false, "a JSON, CBOR, form or XML payload", "<none>") {
+				return
+			}
+		} else {
+			for _, k := range slices.Sorted(maps.Keys(m.JMESPathMatches)) {
+				ok, actual := jmesMatchResult(payload, k, m.JMESPathMatches[k])
+				if !c.add("JMESPath["+k+"]", ok, inspectJMESValue(m.JMESPathMatches[k]), EncodeAsJson(actual)) {
+					return
+				}
+			}
 		}
 	}
 
-	if m.Matcher != nil && !m.Matcher(rr) {
-		return false
+	if len(m.Params) > 0 || len(m.ParamsMatch) > 0 || m.Strict {
+		var form url.Values
+		if rr.HttpRequest != nil {
+			form = rr.HttpRequest.Form
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(m.Params)) {
+			actual, ok := form[k]
+			if !ok {
+				if !c.add("Params["+k+"]", false, inspectValues(m.Params[k]), "<absent>") {
+					return
+				}
+				continue
+			}
+
+			if !c.add("Params["+k+"]", slices.Equal(m.Params[k], actual), inspectValues(m.Params[k]), inspectValues(actual)) {
+				return
+			}
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(m.ParamsMatch)) {
+			actual := form[k]
+			if !c.add("ParamsMatch["+k+"]", slices.ContainsFunc(actual, m.ParamsMatch[k].MatchString), inspectValueMatcher(m.ParamsMatch[k]), inspectValues(actual)) {
+				return
+			}
+		}
+
+		if m.Strict && !c.add("Strict", m.matchStrictParams(form), strconv.Quote(m.Params.Encode()), strconv.Quote(form.Encode())) {
+			return
+		}
 	}
 
-	if len(m.Params) > 0 {
-		// if the request has no params, it cant match something with params...
-		if len(rr.HttpRequest.Form) == 0 {
+	if m.composition != nil && !m.composition.addChecks(c, rr) {
+		return
+	}
+
+	// functions run last, and only if everything else matched, so the other fields can be used as filters
+	if c.failed {
+		if m.InputMatcher != nil {
+			c.skip("InputMatcher")
+		}
+		if m.Matcher != nil {
+			c.skip("Matcher")
+		}
+		return
+	}
+
+	if m.InputMatcher != nil {
+		ok, actual := m.matchInput(rr)
+		if !c.add("InputMatcher", ok, fmt.Sprintf("%T to return true", m.InputMatcher), actual) {
+			return
+		}
+	}
+
+	if m.Matcher != nil {
+		c.add("Matcher", m.Matcher(rr), "true", "false")
+	}
+}
+
+// returns whether the input matcher passed, and a description of what it was given
+func (m *MockedRequest) matchInput(rr *ReceivedRequest) (bool, string) {
+	inputType := inputMatcherType(m.InputMatcher)
+
+	params, ok := rr.sdkParameters()
+	if !ok {
+		return false, "<no typed input>"
+	}
+
+	if reflect.TypeOf(params) != inputType {
+		return false, fmt.Sprintf("%T", params)
+	}
+
+	ret := reflect.ValueOf(m.InputMatcher).Call([]reflect.Value{reflect.ValueOf(params)})
+	if !ret[0].Bool() {
+		return false, fmt.Sprintf("%T returned false", m.InputMatcher)
+	}
+	return true, fmt.Sprintf("%T returned true", m.InputMatcher)
+}
+
+// the form must contain every param, and nothing that is not in Params or ParamsMatch
+func (m *MockedRequest) matchStrictParams(form url.Values) bool {
+	for k := range m.Params {
+		if _, ok := form[k]; !ok {
 			return false
 		}
+	}
 
-		for k, v := range m.Params {
-			if !rr.HttpRequest.Form.Has(k) {
-				// key is missing
+	for k, values := range form {
+		if expected, ok := m.Params[k]; ok {
+			if !slices.Equal(values, expected) {
 				return false
 			}
+			continue
+		}
 
-			if !slices.Equal(v, rr.HttpRequest.Form[k]) {
-				return false
-			}
+		if _, ok := m.ParamsMatch[k]; !ok {
+			return false
 		}
 	}
 
 	return true
 }
 
-func (m *MockedRequest) matchJmespath(rr *ReceivedRequest) bool {
-	// just bail out if there is nothing to match
-	if len(m.JMESPathMatches) == 0 {
-		return true
+func matchBodyJSON(expected any, rr *ReceivedRequest) bool {
+	if len(rr.RawBody) == 0 {
+		return false
 	}
 
-	// you provided Jmes matchers, but this isnt a JSON payload, so it will never match
-	if rr.JsonPayload == nil {
+	var actual any
+	if err := json.Unmarshal(rr.RawBody, &actual); err != nil {
+		// not JSON, so it can never be equivalent
 		return false
 	}
 
-	for k, v := range m.JMESPathMatches {
-		if !JMESMatch(rr.JsonPayload, k, v) {
-			// if any are false, then bail out checking
-			return false
-		}
+	return reflect.DeepEqual(expected, actual)
+}
+
+// BodyJSON in the form it would have if it had been decoded from JSON
+func (m *MockedRequest) bodyJSONValue() (any, error) {
+	if m.bodyJSON == nil {
+		// the request was checked without being prepped
+		return normalizeJSON(m.BodyJSON)
 	}
+	return m.bodyJSON.value, m.bodyJSON.err
+}
 
-	return true
+// JSONContains in the form it would have if it had been decoded from JSON
+func (m *MockedRequest) jsonContainsValue() (any, error) {
+	if m.jsonContains == nil {
+		// the request was checked without being prepped
+		return normalizeJSON(m.JSONContains)
+	}
+	return m.jsonContains.value, m.jsonContains.err
+}
+
+// a JSON document from a mock, normalized once so that a bad value is reported when the mock is added
+type normalizedJSON struct {
+	value any
+	err   error
+}
+
+func newNormalizedJSON(value any) *normalizedJSON {
+	out, err := normalizeJSON(value)
+	return &normalizedJSON{value: out, err: err}
+}
+
+func inspectNormalizedJSON(value any, err error) string {
+	if err != nil {
+		return "<invalid JSON>"
+	}
+	return EncodeAsJson(value)
+}
+
+// checks the values of a single header against an expected header value
+func matchHeaderValue(values []string, expected any) bool {
+	switch exp := expected.(type) {
+	case bool:
+		return (len(values) > 0) == exp
+	case string:
+		return slices.Contains(values, exp)
+	case []string:
+		return slices.Equal(values, exp)
+	case *regexp.Regexp:
+		return slices.ContainsFunc(values, exp.MatchString)
+	case func(string) bool:
+		return slices.ContainsFunc(values, exp)
+	default:
+		// reported by validate
+		return false
+	}
+}
+
+// Returns an error if the value cannot be used to match a header
+func validateHeaderValue(expected any) error {
+	switch expected.(type) {
+	case bool, string, []string, *regexp.Regexp, func(string) bool:
+		return nil
+	default:
+		return fmt.Errorf("header matchers must be a string/[]string/bool/*regexp.Regexp/func(string) bool, got %T", expected)
+	}
+}
+
+// formats a list of received values for display
+func inspectValues(values []string) string {
+	switch len(values) {
+	case 0:
+		return "<absent>"
+	case 1:
+		return strconv.Quote(values[0])
+	default:
+		return fmt.Sprintf("%q", values)
+	}
 }
 
-func (m *MockedRequest) matchRequestStrict(rr *ReceivedRequest) bool {
-	// assume the lazy check has already run
+// like inspectHeaderValue, but literal values are quoted so they line up with inspectValues
+func inspectExpectedHeader(expected any) string {
+	switch exp := expected.(type) {
+	case string:
+		return strconv.Quote(exp)
+	case []string:
+		return inspectValues(exp)
+	default:
+		return inspectHeaderValue(exp)
+	}
+}
 
-	return maps.EqualFunc(rr.HttpRequest.Form, m.Params, func(v1, v2 []string) bool {
-		return slices.Equal(v1, v2)
-	})
+func inspectHeaderValue(expected any) string {
+	switch exp := expected.(type) {
+	case bool:
+		if exp {
+			return "<present>"
+		}
+		return "<absent>"
+	case *regexp.Regexp:
+		return "~" + exp.String()
+	case func(string) bool:
+		return "<func>"
+	default:
+		return fmt.Sprintf("%v", exp)
+	}
+}
+
+// returns the input type of a typed input matcher function. Panics if the function is invalid
+func inputMatcherType(fn any) reflect.Type {
+	typ := reflect.TypeOf(fn)
+	if typ.Kind() != reflect.Func || typ.NumIn() != 1 || typ.NumOut() != 1 || typ.Out(0).Kind() != reflect.Bool || typ.In(0).Kind() != reflect.Pointer {
+		panic(fmt.Sprintf("InputMatcher must be a func(*service.ACTIONInput) bool, got %T", fn))
+	}
+	return typ.In(0)
 }
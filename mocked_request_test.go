@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"regexp"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,6 +19,8 @@ func TestMockedRequest_Inspect(t *testing.T) {
 		Service:   "sts",
 		Action:    "GetCallerIdentity",
 		Body:      "somebody",
+		BodyRegex: regexp.MustCompile(`some.*`),
+		BodyJSON:  map[string]any{"Name": "thing"},
 		Method:    http.MethodGet,
 		Path:      "/blah/blah/some/path",
 		PathRegex: regexp.MustCompile(`/version/([a-z0-9]+)/test`),
@@ -24,6 +28,12 @@ func TestMockedRequest_Inspect(t *testing.T) {
 		Params: url.Values{
 			"TestParam": []string{"thing"},
 		},
+		Headers: map[string]any{
+			"x-amz-target":         "AmazonEC2ContainerServiceV20141113.ListClusters",
+			"If-Match":             true,
+			"X-Amz-Security-Token": false,
+			"User-Agent":           regexp.MustCompile(`myapp/[0-9.]+$`),
+		},
 	}
 
 	result := mr.Inspect()
@@ -37,6 +47,9 @@ func TestMockedRequest_Inspect(t *testing.T) {
 	require.Contains(t, result, "Method=GET")
 	require.Contains(t, result, "Params=TestParam=thing")
 	require.Contains(t, result, "Body=somebody")
+	require.Contains(t, result, "Headers=[If-Match=<present> User-Agent=~myapp/[0-9.]+$ X-Amz-Security-Token=<absent> X-Amz-Target=AmazonEC2ContainerServiceV20141113.ListClusters]")
+	require.Contains(t, result, "BodyRegex=some.*")
+	require.Contains(t, result, `BodyJSON={"Name":"thing"}`)
 }
 
 func TestMockedRequest_matchRequest(t *testing.T) {
@@ -410,6 +423,199 @@ func TestMockedRequest_matchRequest(t *testing.T) {
 			},
 		},
 
+		{
+			name: "BodyExact",
+			mr: &MockedRequest{
+				Body: `{"Name":"thing"}`,
+			},
+			reqs: []submatch{
+				{
+					matches: true,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"Name":"thing"}`),
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"Name": "thing"}`),
+					},
+				},
+				{
+					matches: false,
+					rr:      &ReceivedRequest{},
+				},
+			},
+		},
+
+		{
+			name: "BodyRegex",
+			mr: &MockedRequest{
+				BodyRegex: regexp.MustCompile(`"Name":\s*"rule-[0-9]+"`),
+			},
+			reqs: []submatch{
+				{
+					matches: true,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"Name":"rule-123"}`),
+					},
+				},
+				{
+					matches: true,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"Name": "rule-1", "Extra": true}`),
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"Name":"rule-abc"}`),
+					},
+				},
+			},
+		},
+
+		{
+			name: "BodyJSON",
+			mr: &MockedRequest{
+				BodyJSON: map[string]any{
+					"TableName": "things",
+					"Key": map[string]any{
+						"id": map[string]any{"S": "123"},
+					},
+					"Limit": 5,
+				},
+			},
+			reqs: []submatch{
+				{
+					matches: true,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"TableName":"things","Key":{"id":{"S":"123"}},"Limit":5}`),
+					},
+				},
+				{
+					matches: true,
+					rr: &ReceivedRequest{
+						RawBody: []byte("{\n  \"Limit\": 5.0,\n  \"Key\": {\"id\": {\"S\": \"123\"}},\n  \"TableName\": \"things\"\n}"),
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"TableName":"things","Key":{"id":{"S":"456"}},"Limit":5}`),
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"TableName":"things","Key":{"id":{"S":"123"}},"Limit":5,"Extra":true}`),
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`not json`),
+					},
+				},
+				{
+					matches: false,
+					rr:      &ReceivedRequest{},
+				},
+			},
+		},
+
+		{
+			name: "BodyJSONString",
+			mr: &MockedRequest{
+				BodyJSON: `{"b": [1, 2], "a": "x"}`,
+			},
+			reqs: []submatch{
+				{
+					matches: true,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"a":"x","b":[1,2]}`),
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						RawBody: []byte(`{"a":"x","b":[2,1]}`),
+					},
+				},
+			},
+		},
+
+		{
+			name: "HeadersMatcher",
+			mr: &MockedRequest{
+				Headers: map[string]any{
+					"x-amz-target":         "AmazonEC2ContainerServiceV20141113.ListClusters",
+					"If-Match":             true,
+					"X-Amz-Security-Token": false,
+					"User-Agent":           regexp.MustCompile(`myapp/[0-9.]+$`),
+					"X-Amz-Content-Sha256": func(v string) bool { return len(v) == 64 },
+				},
+			},
+			reqs: []submatch{
+				{
+					matches: true,
+					rr: &ReceivedRequest{
+						HttpRequest: &http.Request{
+							Header: http.Header{
+								"X-Amz-Target":         []string{"AmazonEC2ContainerServiceV20141113.ListClusters"},
+								"If-Match":             []string{`"etag"`},
+								"User-Agent":           []string{"aws-sdk-go-v2/1.0 myapp/1.2.3"},
+								"X-Amz-Content-Sha256": []string{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+							},
+						},
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						HttpRequest: &http.Request{
+							Header: http.Header{
+								"X-Amz-Target":         []string{"AmazonEC2ContainerServiceV20141113.ListClusters"},
+								"If-Match":             []string{`"etag"`},
+								"User-Agent":           []string{"aws-sdk-go-v2/1.0 myapp/1.2.3"},
+								"X-Amz-Content-Sha256": []string{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+								"X-Amz-Security-Token": []string{"token"},
+							},
+						},
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						HttpRequest: &http.Request{
+							Header: http.Header{
+								"X-Amz-Target":         []string{"AmazonEC2ContainerServiceV20141113.ListClusters"},
+								"User-Agent":           []string{"aws-sdk-go-v2/1.0 myapp/1.2.3"},
+								"X-Amz-Content-Sha256": []string{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+							},
+						},
+					},
+				},
+				{
+					matches: false,
+					rr: &ReceivedRequest{
+						HttpRequest: &http.Request{
+							Header: http.Header{
+								"X-Amz-Target":         []string{"AmazonEC2ContainerServiceV20141113.ListServices"},
+								"If-Match":             []string{`"etag"`},
+								"User-Agent":           []string{"aws-sdk-go-v2/1.0 myapp/1.2.3"},
+								"X-Amz-Content-Sha256": []string{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+							},
+						},
+					},
+				},
+				{
+					matches: false,
+					rr:      &ReceivedRequest{},
+				},
+			},
+		},
+
 		{
 			name: "StrictParamsMatcher",
 			mr: &MockedRequest{
@@ -462,3 +668,85 @@ func TestMockedRequest_matchRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchHeaderValue(t *testing.T) {
+	require.True(t, matchHeaderValue([]string{"a", "b"}, []string{"a", "b"}))
+	require.False(t, matchHeaderValue([]string{"a"}, []string{"a", "b"}))
+	require.True(t, matchHeaderValue(nil, false))
+	require.False(t, matchHeaderValue(nil, "a"))
+	require.False(t, matchHeaderValue(nil, regexp.MustCompile(`.*`)))
+
+	require.False(t, matchHeaderValue([]string{"1"}, 1))
+}
+
+func TestInputMatcherType(t *testing.T) {
+	require.Equal(t, reflect.TypeFor[*sts.GetCallerIdentityInput](), inputMatcherType(func(*sts.GetCallerIdentityInput) bool { return true }))
+
+	badFuncs := []any{
+		"nope",
+		func(sts.GetCallerIdentityInput) bool { return true },
+		func(*sts.GetCallerIdentityInput) {},
+		func(*sts.GetCallerIdentityInput) (bool, error) { return true, nil },
+		func(*sts.GetCallerIdentityInput, *ReceivedRequest) bool { return true },
+	}
+
+	for _, fn := range badFuncs {
+		require.Panicsf(t, func() { inputMatcherType(fn) }, "expected panic for %T", fn)
+		require.Panicsf(t, func() { (&MockedRequest{InputMatcher: fn}).prep() }, "expected panic for %T", fn)
+	}
+
+	mr := &MockedRequest{InputMatcher: func(*sts.GetCallerIdentityInput) bool { return true }}
+	require.Contains(t, mr.Inspect(), "InputMatcher=func(*sts.GetCallerIdentityInput) bool")
+
+	// without the middleware, there is nothing to match against
+	require.False(t, mr.matchRequest(&ReceivedRequest{}))
+}
+
+func TestMockedRequest_validate(t *testing.T) {
+	tables := []struct {
+		name string
+		mr   *MockedRequest
+		err  string
+	}{
+		{name: "Empty", mr: &MockedRequest{}},
+		{name: "BodyJSON", mr: &MockedRequest{BodyJSON: map[string]any{"a": 1}}},
+		{name: "BodyJSONInvalid", mr: &MockedRequest{BodyJSON: `{"a":`}, err: "BodyJSON: value is not valid JSON"},
+		{name: "BodyJSONNotEncodable", mr: &MockedRequest{BodyJSON: map[string]any{"a": make(chan int)}}, err: "BodyJSON: value cannot be encoded as JSON"},
+		{name: "JSONContains", mr: &MockedRequest{JSONContains: `{"a":1}`}},
+		{name: "JSONContainsInvalid", mr: &MockedRequest{JSONContains: []byte(`{"a"}`)}, err: "JSONContains: value is not valid JSON"},
+		{name: "Composed", mr: AllOf(&MockedRequest{}, &MockedRequest{BodyJSON: "nope"}), err: "AllOf[1].BodyJSON: value is not valid JSON"},
+		{name: "Headers", mr: &MockedRequest{Headers: map[string]any{"x-thing": true, "Accept": "text/plain"}}},
+		{name: "HeadersInvalid", mr: &MockedRequest{Headers: map[string]any{"x-thing": 1}}, err: "Headers[X-Thing]: header matchers must be a"},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			table.mr.prep()
+			err := table.mr.validate()
+			if table.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, table.err)
+
+			// checking a request must not panic
+			require.False(t, table.mr.matchRequest(&ReceivedRequest{RawBody: []byte(`{"a":1}`)}))
+			require.NotPanics(t, func() { _ = table.mr.Inspect() })
+		})
+	}
+}
+
+func TestMockedRequest_specificity(t *testing.T) {
+	require.Equal(t, 0, (&MockedRequest{}).specificity())
+	require.Equal(t, 2, (&MockedRequest{Service: "sts", Action: "GetCallerIdentity"}).specificity())
+	require.Equal(t, 5, (&MockedRequest{
+		Service: "ecs",
+		Strict:  true,
+		Params: url.Values{
+			"Names.member.1": []string{"a"},
+			"Names.member.2": []string{"b"},
+		},
+		Headers: map[string]any{"If-Match": true},
+	}).specificity())
+}
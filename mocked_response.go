@@ -15,6 +15,12 @@ const (
 	ContentTypeXML  = "text/xml"
 	ContentTypeJSON = "application/x-amz-json-1.1"
 	ContentTypeText = "text/plain"
+
+	// Used by DynamoDB, SQS, Step Functions, Timestream, etc. JSON responses use the
+	// same version as the request, so this rarely needs to be set
+	ContentTypeJSON10 = "application/x-amz-json-1.0"
+
+	ContentTypeRestJSON = "application/json"
 )
 
 var (
@@ -44,11 +50,21 @@ type MockedResponse struct {
 	// func(*ReceivedRequest) (*service.ACTIONOutput, error) = return the result type directly, or error
 	// func(*ReceivedRequest, *service.ACTIONInput) (*service.ACTIONOutput, error) = return the result type directly, or error
 	// func(*service.ACTIONInput) (*service.ACTIONOutput, error) = return the result type directly, or error
+	//
+	// With [ResponseEncodingRestJSON], map keys that contain a dash (X-Amz-Function-Error) are sent as headers,
+	// and a []byte or io.Reader value is sent as the raw payload. Struct fields can use the tags
+	// `location:"header" locationName:"X-Amz-Function-Error"` or `location:"payload"` to do the same.
+	//
+	// With [ResponseEncodingRestXML], the same rules apply. S3 members that AWS sends as headers
+	// (ETag, VersionId, ContentRange, Metadata, etc) are sent as headers, so SDK output types such as
+	// *s3.GetObjectOutput can be used. The Body of a GetObjectOutput is read once, so it can only be returned one time.
 	Body any
 
 	// Do not wrap the xml response in ACTIONResponse>ACTIONResult
 	DoNotWrap bool
-	RootTag   string
+
+	// The root element for XML responses that are not wrapped, or that use [ResponseEncodingRestXML]
+	RootTag string
 
 	// If provided, then all other fields are ignored, and the user
 	// is responsible for building an HTTP response themselves
@@ -71,6 +87,14 @@ func (m *MockedResponse) prep() {
 	}
 }
 
+// Returns an error if this response is configured in a way that can never work
+func (m *MockedResponse) validate() error {
+	if m.Body == nil {
+		return nil
+	}
+	return validateBodyFunc(reflect.TypeOf(m.Body))
+}
+
 func (m *MockedResponse) getResponse(rr *ReceivedRequest) *httpResponse {
 
 	if m.Handler != nil {
@@ -124,7 +148,7 @@ func (m *MockedResponse) getResponse(rr *ReceivedRequest) *httpResponse {
 		if len(respRet) > 2 {
 			respContentType = respRet[2].String()
 		} else {
-			respContentType = inferContentType(respBody)
+			respContentType = rr.inferContentType(respBody)
 		}
 
 		return &httpResponse{
@@ -137,7 +161,11 @@ func (m *MockedResponse) getResponse(rr *ReceivedRequest) *httpResponse {
 		m.rawBody = rBody.String()
 		// m.ContentType = ContentTypeText
 		if m.ContentType == "" && len(m.rawBody) > 1 {
-			m.ContentType = inferContentType(m.rawBody)
+			return &httpResponse{
+				Body:        m.rawBody,
+				StatusCode:  m.StatusCode,
+				contentType: rr.inferContentType(m.rawBody),
+			}
 		}
 		return &httpResponse{
 			Body:        m.rawBody,
@@ -148,13 +176,27 @@ func (m *MockedResponse) getResponse(rr *ReceivedRequest) *httpResponse {
 	case reflect.Map, reflect.Array, reflect.Slice, reflect.Struct:
 
 		switch {
+		case m.Encoding == ResponseEncodingRestJSON:
+			return m.encodeRestJSON(rr, rBody)
+
+		case m.Encoding == ResponseEncodingRestXML:
+			return m.encodeRestXML(rr, rBody, actionName)
+
+		case m.Encoding == ResponseEncodingCBOR:
+			fallthrough
+		case m.Encoding == ResponseEncodingDefault && rr.AssumedResponseType == ContentTypeCBOR:
+			return m.encodeCbor(rr, rBody)
+
+		case rr.Service == "ec2" && !m.DoNotWrap && (m.Encoding == ResponseEncodingDefault || m.Encoding == ResponseEncodingXML):
+			return m.encodeEC2Query(rr, rBody, actionName)
+
 		case m.Encoding == ResponseEncodingJSON:
 			fallthrough
 		case m.Encoding == ResponseEncodingDefault && rr.AssumedResponseType == ContentTypeJSON:
 			return &httpResponse{
 				Body:        EncodeAsJson(m.Body),
 				StatusCode:  m.StatusCode,
-				contentType: ContentTypeJSON,
+				contentType: rr.jsonContentType(),
 			}
 
 		case m.Encoding == ResponseEncodingXML:
@@ -240,7 +282,8 @@ func (m *MockedResponse) processDirectRequest(rr *ReceivedRequest) *httpResponse
 	body := m.Body
 	var err error
 
-	if rr.HttpRequest == nil || len(rr.HttpRequest.Header) == 0 {
+	// requests that did not come through the mocker middleware can not be direct requests
+	if rr.HttpRequest == nil || rr.HttpRequest.Header.Get(mwHeaderRequestId) == "" {
 		return nil
 	}
 	reqId, perr := strconv.ParseUint(rr.HttpRequest.Header.Get(mwHeaderRequestId), 10, 64)
@@ -1,9 +1,12 @@
 package awsmocker
 
 import (
+	"io"
 	"maps"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
@@ -45,6 +48,221 @@ func TestMockedResponse_getResponse(t *testing.T) {
 				require.Contains(t, bodyRaw, "<GetCallerIdentityResponse>")
 			},
 		},
+		{
+			name: "RestJSONHeaders",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestJSON,
+				Body: map[string]any{
+					"X-Amz-Function-Error": "Unhandled",
+					"X-Amz-Log-Result":     aws.String("bG9ncw=="),
+					"X-Amz-Missing":        (*string)(nil),
+					"FunctionName":         "thing",
+					"Timeout":              30,
+				},
+			},
+			rr: &ReceivedRequest{Service: "lambda", Action: "GetFunctionConfiguration"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Equal(t, ContentTypeRestJSON, hr.contentType)
+				require.Equal(t, map[string]string{"X-Amz-Function-Error": "Unhandled", "X-Amz-Log-Result": "bG9ncw=="}, hr.extraHeaders)
+				require.JSONEq(t, `{"FunctionName":"thing","Timeout":30}`, hr.Body)
+			},
+		},
+		{
+			name: "RestJSONPayload",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestJSON,
+				Body: map[string]any{
+					"X-Amz-Executed-Version": "$LATEST",
+					"Payload":                []byte(`{"result":"ok"}`),
+				},
+			},
+			rr: &ReceivedRequest{Service: "lambda", Action: "Invoke"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Equal(t, ContentTypeRestJSON, hr.contentType)
+				require.Equal(t, "$LATEST", hr.extraHeaders["X-Amz-Executed-Version"])
+				require.Equal(t, `{"result":"ok"}`, string(hr.bodyRaw))
+			},
+		},
+		{
+			name: "RestJSONStruct",
+			mr: &MockedResponse{
+				Encoding:    ResponseEncodingRestJSON,
+				ContentType: "application/octet-stream",
+				Body: &struct {
+					ArchiveId string    `location:"header" locationName:"x-amz-archive-id"`
+					Checksum  *string   `location:"header" locationName:"x-amz-sha256-tree-hash"`
+					Body      io.Reader `location:"payload"`
+					Ignored   string    `json:"-"`
+				}{
+					ArchiveId: "archive-1",
+					Checksum:  aws.String("abc"),
+					Body:      strings.NewReader("archive contents"),
+				},
+			},
+			rr: &ReceivedRequest{Service: "glacier", Action: "GetJobOutput"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Equal(t, "application/octet-stream", hr.contentType)
+				require.Equal(t, map[string]string{"x-amz-archive-id": "archive-1", "x-amz-sha256-tree-hash": "abc"}, hr.extraHeaders)
+				require.Equal(t, "archive contents", string(hr.bodyRaw))
+			},
+		},
+		{
+			name: "RestJSONStructBody",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestJSON,
+				Body: struct {
+					Name    string `json:"name"`
+					Version int
+				}{"cluster", 2},
+			},
+			rr: &ReceivedRequest{Service: "eks", Action: "DescribeCluster"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Empty(t, hr.extraHeaders)
+				require.JSONEq(t, `{"name":"cluster","Version":2}`, hr.Body)
+			},
+		},
+		{
+			name: "RestJSONTwoPayloads",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestJSON,
+				Body: map[string]any{
+					"Payload": []byte("a"),
+					"Other":   []byte("b"),
+				},
+			},
+			rr: &ReceivedRequest{Service: "lambda", Action: "Invoke"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Contains(t, hr.Body, "only one member can be the payload")
+			},
+		},
+		{
+			name: "RestJSONPayloadWithBody",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestJSON,
+				Body: map[string]any{
+					"Payload":      []byte("a"),
+					"FunctionName": "thing",
+					"Missing":      (*string)(nil),
+				},
+			},
+			rr: &ReceivedRequest{Service: "lambda", Action: "Invoke"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Contains(t, hr.Body, "members cannot be sent in the body along with a payload: FunctionName")
+			},
+		},
+		{
+			name: "RestJSONLambdaMembers",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestJSON,
+				Body: map[string]any{
+					"FunctionError": aws.String("Handled"),
+					"StatusCode":    int32(202),
+					"Payload":       []byte(`{}`),
+				},
+			},
+			rr: &ReceivedRequest{Service: "lambda", Action: "Invoke"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Equal(t, 202, hr.StatusCode)
+				require.Equal(t, map[string]string{"X-Amz-Function-Error": "Handled"}, hr.extraHeaders)
+				require.Equal(t, `{}`, string(hr.bodyRaw))
+			},
+		},
+		{
+			name: "RestXMLPayloadWithBody",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestXML,
+				Body: map[string]any{
+					"Body":     strings.NewReader("contents"),
+					"Contents": []map[string]any{{"Key": "a"}},
+				},
+			},
+			rr: &ReceivedRequest{Service: "s3", Action: "GetObject", AssumedResponseType: ContentTypeXML},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Contains(t, string(hr.bodyRaw)+hr.Body, "members cannot be sent in the body along with a payload: Contents")
+			},
+		},
+		{
+			name: "EC2Query",
+			mr: &MockedResponse{
+				Body: map[string]any{
+					"Vpcs": []map[string]any{{"VpcId": "vpc-1", "CidrBlock": "10.0.0.0/16"}},
+				},
+			},
+			rr: &ReceivedRequest{Service: "ec2", Action: "DescribeVpcs", AssumedResponseType: ContentTypeXML},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Equal(t, ContentTypeXML, hr.contentType)
+				body := string(hr.bodyRaw)
+				require.Contains(t, body, `<DescribeVpcsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">`)
+				require.Contains(t, body, "<requestId>")
+				require.Contains(t, body, "<vpcSet>\n    <item>\n")
+				require.Contains(t, body, "<cidrBlock>10.0.0.0/16</cidrBlock>")
+				require.NotContains(t, body, "DescribeVpcsResult")
+				require.NotContains(t, body, "ResponseMetadata")
+			},
+		},
+		{
+			name: "RestXMLRoute53",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestXML,
+				Body: map[string]any{
+					"IsTruncated": false,
+					"HostedZones": []map[string]any{
+						{"Id": "/hostedzone/Z1", "Name": "example.com."},
+					},
+				},
+			},
+			rr: &ReceivedRequest{Service: "route53", Action: "ListHostedZones"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Equal(t, ContentTypeRestXML, hr.contentType)
+				body := string(hr.bodyRaw)
+				require.Contains(t, body, `<ListHostedZonesResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">`)
+				require.Contains(t, body, "<HostedZones>\n    <HostedZone>\n      <Id>/hostedzone/Z1</Id>")
+				require.NotContains(t, body, "ResponseMetadata")
+			},
+		},
+		{
+			name: "RestXMLS3Headers",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestXML,
+				Body: &struct {
+					ETag         *string
+					VersionId    *string
+					LastModified *time.Time
+					Metadata     map[string]string
+					Body         io.Reader
+				}{
+					ETag:         aws.String(`"abc"`),
+					VersionId:    aws.String("v1"),
+					LastModified: aws.Time(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)),
+					Metadata:     map[string]string{"owner": "bob"},
+					Body:         strings.NewReader("contents"),
+				},
+			},
+			rr: &ReceivedRequest{Service: "s3", Action: "GetObject"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Equal(t, map[string]string{
+					"ETag":             `"abc"`,
+					"X-Amz-Version-Id": "v1",
+					"Last-Modified":    "Mon, 06 May 2024 07:08:09 GMT",
+					"X-Amz-Meta-owner": "bob",
+				}, hr.extraHeaders)
+				require.Equal(t, "contents", string(hr.bodyRaw))
+				require.Equal(t, "text/plain; charset=utf-8", hr.contentType)
+			},
+		},
+		{
+			name: "RestXMLPayloadShape",
+			mr: &MockedResponse{
+				Encoding: ResponseEncodingRestXML,
+				Body: map[string]any{
+					"CopyObjectResult": map[string]any{"ETag": `"copied"`},
+				},
+			},
+			rr: &ReceivedRequest{Service: "s3", Action: "CopyObject"},
+			exp: func(t *testing.T, hr *httpResponse) {
+				require.Contains(t, string(hr.bodyRaw), `<CopyObjectResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">`)
+			},
+		},
 	}
 
 	for _, table := range tables {
@@ -55,6 +273,21 @@ func TestMockedResponse_getResponse(t *testing.T) {
 	}
 }
 
+func TestMockedResponse_EC2RequestId(t *testing.T) {
+	mr := &MockedResponse{Body: map[string]any{"Return": true}}
+	rr := &ReceivedRequest{Service: "ec2", Action: "DeleteVpc", AssumedResponseType: ContentTypeXML}
+
+	requestId := func() string {
+		body := string(mr.getResponse(rr).bodyRaw)
+		_, after, _ := strings.Cut(body, "<requestId>")
+		id, _, _ := strings.Cut(after, "</requestId>")
+		require.NotEmpty(t, id)
+		return id
+	}
+
+	require.NotEqual(t, requestId(), requestId())
+}
+
 func TestProcessDirectRequestFunc(t *testing.T) {
 
 	entry := mwDBEntry{
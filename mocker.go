@@ -2,9 +2,11 @@ package awsmocker
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -44,7 +46,23 @@ type mocker struct {
 
 	// originalEnv map[string]*string
 
-	mocks []*MockedEndpoint
+	mocks   []*MockedEndpoint
+	mocksMu sync.RWMutex
+
+	// the mocks the mocker was started with
+	initialMocks []*MockedEndpoint
+
+	// current state of any scenarios used by the mocks
+	scenarios *scenarioStore
+
+	// groups of mocks that must be matched in order
+	orderedMocks [][]*MockedEndpoint
+
+	// every request received, in order
+	journal *requestJournal
+
+	// what to do with mocks that were never matched
+	unusedMocks unusedMocksMode
 
 	// counter used by the middleware to track requests
 	mwReqCounter *atomic.Uint64
@@ -59,6 +77,8 @@ func (m *mocker) init() {
 	// m.originalEnv = make(map[string]*string, 10)
 	m.requestLog = &sync.Map{}
 	m.mwReqCounter = &atomic.Uint64{}
+	m.scenarios = newScenarioStore()
+	m.journal = newRequestJournal()
 }
 
 // Overrides an environment variable and then adds it to the stack to undo later
@@ -104,8 +124,14 @@ func (m *mocker) Start() {
 
 	for i := range m.mocks {
 		m.mocks[i].prep()
+
+		if err := m.validateMock(m.mocks[i]); err != nil {
+			m.t.Errorf("Invalid mock %s: %s", m.mocks[i].Request.Inspect(), err)
+		}
 	}
 
+	m.initialMocks = slices.Clone(m.mocks)
+
 	// ts := httptest.NewServer(m)
 	// m.httpServer = ts
 
@@ -122,12 +148,29 @@ func (m *mocker) Start() {
 
 }
 
+// Returns an error if the mock can never work with this mocker
+func (m *mocker) validateMock(mock *MockedEndpoint) error {
+	if err := mock.validate(); err != nil {
+		return err
+	}
+
+	if m.noMiddleware && mock.Request.usesInputMatcher() {
+		return errors.New("InputMatcher needs the mocker middleware, which was disabled by WithoutMiddleware")
+	}
+
+	return nil
+}
+
 func (m *mocker) Shutdown() {
 	if m.httpServer != nil {
 		m.httpServer.Close()
 	}
 	m.requestLog.Clear()
 
+	m.verifyExpectations()
+	m.verifyOrder()
+	m.reportUnusedMocks()
+
 	// m.revertEnv()
 }
 
@@ -171,21 +214,83 @@ func (m *mocker) handleRequest(req *http.Request) (*http.Request, *http.Response
 		recvReq.DebugDump()
 	}
 
-	for _, mockEndpoint := range m.mocks {
-		if mockEndpoint.matchRequest(recvReq) {
-			// increment it's matcher count
-			mockEndpoint.Request.incMatchCount()
+	if params, ok := recvReq.sdkParameters(); ok {
+		recvReq.Input = params
+	}
+
+	m.journal.record(recvReq)
 
-			// build the response
-			return req, mockEndpoint.getResponse(recvReq).toHttpResponse(req)
+	if mockEndpoint := m.claimMock(recvReq); mockEndpoint != nil {
+		m.journal.update(func() {
+			recvReq.MatchedEndpoint = mockEndpoint
+		})
+
+		if mockEndpoint.Scenario != "" && mockEndpoint.NewState != "" {
+			m.scenarios.set(mockEndpoint.Scenario, mockEndpoint.NewState)
 		}
+
+		// build the response
+		return req, m.respond(recvReq, mockEndpoint.getResponse(recvReq))
 	}
 
 	if !m.doNotFailUnhandled {
-		m.t.Errorf("No matching request mock was found for this request: %s", recvReq.Inspect())
+		m.t.Errorf("No matching request mock was found for this request: %s%s", recvReq.Inspect(), m.nearMissReport(recvReq))
+	}
+
+	return req, m.respond(recvReq, generateErrorStruct(http.StatusNotImplemented, "AccessDenied", "No matching request mock was found for this").getResponse(recvReq))
+}
+
+// converts the response and records the status code that was sent back
+func (m *mocker) respond(rr *ReceivedRequest, resp *httpResponse) *http.Response {
+	httpResp := resp.toHttpResponse(rr.HttpRequest)
+	m.journal.update(func() {
+		rr.StatusCode = httpResp.StatusCode
+	})
+	return httpResp
+}
+
+// Selects the mock for the request and counts the match. If another request took the last
+// match of the selected mock (MaxMatchCount) in the meantime, the selection is done again.
+func (m *mocker) claimMock(recvReq *ReceivedRequest) *MockedEndpoint {
+	for {
+		mockEndpoint := m.selectMock(recvReq)
+		if mockEndpoint == nil || mockEndpoint.Request.tryIncMatchCount() {
+			return mockEndpoint
+		}
+	}
+}
+
+// Finds the best mock for the request. Higher priority wins, then higher specificity.
+// Ties go to the user's mocks over the default mocks, and then to the mock that was provided first.
+func (m *mocker) selectMock(recvReq *ReceivedRequest) *MockedEndpoint {
+	var (
+		selected    *MockedEndpoint
+		specificity int
+	)
+
+	for _, mockEndpoint := range m.activeMocks() {
+		if !mockEndpoint.matchRequest(recvReq) {
+			continue
+		}
+
+		score := mockEndpoint.specificity()
+
+		better := selected == nil || mockEndpoint.Priority > selected.Priority
+		if !better && mockEndpoint.Priority == selected.Priority {
+			better = score > specificity || (score == specificity && isDefaultMock(selected) && !isDefaultMock(mockEndpoint))
+		}
+
+		if better {
+			selected = mockEndpoint
+			specificity = score
+		}
+	}
+
+	if selected != nil && m.debugTraffic {
+		fmt.Fprintf(DebugOutputWriter, "--- AWSMOCKER SELECTED MOCK: %s (priority=%d specificity=%d)\n", selected.Request.Inspect(), selected.Priority, specificity)
 	}
 
-	return req, generateErrorStruct(http.StatusNotImplemented, "AccessDenied", "No matching request mock was found for this").getResponse(recvReq).toHttpResponse(req)
+	return selected
 }
 
 func (m *mocker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -21,16 +21,46 @@ type MockerInfo interface {
 
 	// Aws configuration to use
 	Config() aws.Config
+
+	// Aws configuration that uses the given region
+	ConfigForRegion(region string) aws.Config
+
+	// Aws configuration with credentials for the given account. Use MockedRequest.AccountId to match its requests
+	ConfigForAccount(accountId string) aws.Config
+
+	// Returns the current state of the named scenario
+	ScenarioState(name string) string
+
+	// Forces the named scenario into the given state
+	SetScenarioState(name, state string)
+
+	// Adds mocks to the running mocker. Returns a handle for each mock that can be used to remove it.
+	AddMocks(mocks ...*MockedEndpoint) []MockHandle
+
+	// Removes a mock that was previously added. Returns false if it was not found.
+	RemoveMock(handle MockHandle) bool
+
+	// Restores the mocks to the set that the mocker was started with.
+	ResetMocks()
+
+	// Resets the match counts and response sequences of every mock, and clears the received requests.
+	ResetCounts()
+
+	// Every request the mocker received, in order. This includes requests that did not match a mock.
+	Requests() []*ReceivedRequest
+
+	// The received requests for the given service and action. An empty value matches anything.
+	RequestsFor(service, action string) []*ReceivedRequest
 }
 
 var _ MockerInfo = (*mocker)(nil)
 
-func (m mocker) Config() aws.Config {
+func (m *mocker) Config() aws.Config {
 	return m.awsConfig
 }
 
 // Use this for custom proxy configurations
-func (m mocker) Proxy() func(*http.Request) (*url.URL, error) {
+func (m *mocker) Proxy() func(*http.Request) (*url.URL, error) {
 	uri, err := url.Parse(m.ProxyURL())
 	return func(_ *http.Request) (*url.URL, error) {
 		return uri, err
@@ -42,7 +72,7 @@ func (m *mocker) ProxyURL() string {
 	return m.httpServer.URL
 }
 
-func (m mocker) IMDSClient() *imds.Client {
+func (m *mocker) IMDSClient() *imds.Client {
 	return imds.NewFromConfig(m.Config())
 }
 
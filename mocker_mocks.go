@@ -0,0 +1,82 @@
+package awsmocker
+
+import "slices"
+
+// A reference to a mock that was added with AddMocks. Use it to remove the mock later.
+type MockHandle struct {
+	endpoint *MockedEndpoint
+}
+
+// The endpoint this handle refers to
+func (h MockHandle) Endpoint() *MockedEndpoint {
+	return h.endpoint
+}
+
+// returns a snapshot of the current mocks that is safe to iterate
+func (m *mocker) activeMocks() []*MockedEndpoint {
+	m.mocksMu.RLock()
+	defer m.mocksMu.RUnlock()
+	return slices.Clone(m.mocks)
+}
+
+// Adds mocks to a running mocker. Returns a handle for each mock, in the order they were given.
+func (m *mocker) AddMocks(mocks ...*MockedEndpoint) []MockHandle {
+	handles := make([]MockHandle, 0, len(mocks))
+
+	m.mocksMu.Lock()
+	defer m.mocksMu.Unlock()
+
+	for _, mock := range mocks {
+		if mock == nil {
+			continue
+		}
+
+		handles = append(handles, MockHandle{endpoint: mock})
+
+		if slices.Contains(m.mocks, mock) {
+			continue
+		}
+
+		mock.prep()
+		if err := m.validateMock(mock); err != nil {
+			m.t.Errorf("Invalid mock %s: %s", mock.Request.Inspect(), err)
+		}
+
+		m.mocks = append(m.mocks, mock)
+	}
+
+	return handles
+}
+
+// Removes a mock from the mocker. Returns false if the mock was not found.
+// Removed mocks no longer have their call expectations checked.
+func (m *mocker) RemoveMock(handle MockHandle) bool {
+	m.mocksMu.Lock()
+	defer m.mocksMu.Unlock()
+
+	idx := slices.Index(m.mocks, handle.endpoint)
+	if idx < 0 {
+		return false
+	}
+
+	m.mocks = slices.Delete(m.mocks, idx, idx+1)
+	return true
+}
+
+// Restores the mocks to the set that the mocker was started with.
+func (m *mocker) ResetMocks() {
+	m.mocksMu.Lock()
+	defer m.mocksMu.Unlock()
+
+	m.mocks = slices.Clone(m.initialMocks)
+}
+
+// Resets the match counts and response sequences of every mock, and clears the received requests.
+// Scenario states are not changed.
+func (m *mocker) ResetCounts() {
+	for _, mock := range m.activeMocks() {
+		mock.resetCounts()
+	}
+
+	m.journal.reset()
+}
@@ -0,0 +1,133 @@
+package awsmocker_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestRuntimeMockManagement(t *testing.T) {
+	listClusters := func(arn string) *awsmocker.MockedEndpoint {
+		return awsmocker.NewSimpleMockedEndpoint("ecs", "ListClusters", map[string]any{
+			"clusterArns": []string{arn},
+		})
+	}
+
+	initial := listClusters("initial")
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutFailingUnhandledRequests(), awsmocker.WithMocks(initial))
+	client := ecs.NewFromConfig(m.Config())
+
+	list := func() string {
+		resp, err := client.ListClusters(context.TODO(), &ecs.ListClustersInput{})
+		if err != nil {
+			return err.Error()
+		}
+		return resp.ClusterArns[0]
+	}
+
+	require.Equal(t, "initial", list())
+
+	handles := m.AddMocks(&awsmocker.MockedEndpoint{
+		Priority: 1,
+		Request:  &awsmocker.MockedRequest{Service: "ecs", Action: "ListClusters"},
+		Response: &awsmocker.MockedResponse{Body: map[string]any{"clusterArns": []string{"added"}}},
+	}, nil)
+	require.Len(t, handles, 1)
+	require.Equal(t, "added", list())
+	require.Equal(t, 1, handles[0].Endpoint().Request.MatchCount())
+
+	require.True(t, m.RemoveMock(handles[0]))
+	require.False(t, m.RemoveMock(handles[0]))
+	require.Equal(t, "initial", list())
+
+	require.False(t, m.RemoveMock(awsmocker.MockHandle{}))
+
+	// remove the initial mock too, then restore it
+	for _, h := range m.AddMocks(initial) {
+		require.True(t, m.RemoveMock(h))
+	}
+	require.Contains(t, list(), "AccessDenied")
+
+	m.ResetMocks()
+	require.Equal(t, "initial", list())
+	require.Equal(t, 3, initial.Request.MatchCount())
+
+	m.ResetCounts()
+	require.Equal(t, 0, initial.Request.MatchCount())
+}
+
+func TestRuntimeMockManagement_Concurrent(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		awsmocker.NewSimpleMockedEndpoint("ecs", "ListClusters", map[string]any{}),
+	))
+	client := ecs.NewFromConfig(m.Config())
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				_, err := client.ListClusters(context.TODO(), &ecs.ListClustersInput{})
+				require.NoError(t, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				for _, h := range m.AddMocks(awsmocker.NewSimpleMockedEndpoint("ecs", "ListServices", map[string]any{})) {
+					m.RemoveMock(h)
+				}
+				m.ResetCounts()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMaxMatchCount_Concurrent(t *testing.T) {
+	limited := &awsmocker.MockedEndpoint{
+		Priority: 10,
+		Request: &awsmocker.MockedRequest{
+			Service:       "ecs",
+			Action:        "ListClusters",
+			MaxMatchCount: 1,
+		},
+		Response: &awsmocker.MockedResponse{
+			Body: map[string]any{"clusterArns": []string{"limited"}},
+		},
+	}
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		limited,
+		awsmocker.NewSimpleMockedEndpoint("ecs", "ListClusters", map[string]any{"clusterArns": []string{"fallback"}}),
+	))
+	client := ecs.NewFromConfig(m.Config())
+
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		limitedCount int
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.ListClusters(context.TODO(), &ecs.ListClustersInput{})
+			require.NoError(t, err)
+			if resp.ClusterArns[0] == "limited" {
+				mu.Lock()
+				limitedCount++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, limitedCount)
+	require.Equal(t, 1, limited.Request.MatchCount())
+}
@@ -2,14 +2,19 @@ package awsmocker_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/jmespath/go-jmespath"
@@ -273,6 +278,397 @@ func TestDynamicMocker(t *testing.T) {
 	}
 }
 
+func TestBodyMatching(t *testing.T) {
+	putRuleMock := func(ruleName, arn string) *awsmocker.MockedEndpoint {
+		return &awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "events",
+				Action:  "PutRule",
+				BodyJSON: map[string]any{
+					"Name":         ruleName,
+					"EventPattern": `{"source":["aws.ecs"]}`,
+				},
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"RuleArn": arn,
+				},
+			},
+		}
+	}
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		putRuleMock("first", "arn:first"),
+		putRuleMock("second", "arn:second"),
+	))
+
+	client := eventbridge.NewFromConfig(m.Config())
+
+	for _, name := range []string{"second", "first"} {
+		resp, err := client.PutRule(context.TODO(), &eventbridge.PutRuleInput{
+			Name:         aws.String(name),
+			EventPattern: aws.String(`{"source":["aws.ecs"]}`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "arn:"+name, *resp.RuleArn)
+	}
+}
+
+func TestInvalidBodyJSONReportedAtStart(t *testing.T) {
+	tm := NewTestingMock(t)
+
+	m := awsmocker.Start(tm, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(&awsmocker.MockedEndpoint{
+		Request: &awsmocker.MockedRequest{
+			Service:  "events",
+			Action:   "PutRule",
+			BodyJSON: `{"Name":`,
+		},
+		Response: &awsmocker.MockedResponse{
+			Body: map[string]any{"RuleArn": "arn:rule"},
+		},
+	}))
+
+	require.True(t, tm.errored)
+	require.Contains(t, tm.errorMessages[0], "Invalid mock MReq<Service=events Action=PutRule BodyJSON=<invalid JSON>>: BodyJSON: value is not valid JSON")
+
+	// requests are still handled, the mock just never matches
+	_, err := eventbridge.NewFromConfig(m.Config()).PutRule(context.TODO(), &eventbridge.PutRuleInput{Name: aws.String("rule")})
+	require.Error(t, err)
+}
+
+func TestInvalidHeaderReportedAtStart(t *testing.T) {
+	tm := NewTestingMock(t)
+
+	m := awsmocker.Start(tm, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(&awsmocker.MockedEndpoint{
+		Request: &awsmocker.MockedRequest{
+			Service: "ecs",
+			Headers: map[string]any{"X-Amz-Target": 1},
+		},
+		Response: &awsmocker.MockedResponse{
+			Body: map[string]any{"clusterArns": []string{}},
+		},
+	}))
+
+	require.True(t, tm.errored)
+	require.Contains(t, tm.errorMessages[0], "Headers[X-Amz-Target]: header matchers must be a string/[]string/bool/*regexp.Regexp/func(string) bool, got int")
+
+	// requests are still handled, the mock just never matches
+	require.NotPanics(t, func() {
+		_, err := ecs.NewFromConfig(m.Config()).ListClusters(context.TODO(), &ecs.ListClustersInput{})
+		require.Error(t, err)
+	})
+}
+
+func TestHeaderMatching(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "ecs",
+				Headers: map[string]any{
+					"X-Amz-Target":         "AmazonEC2ContainerServiceV20141113.ListClusters",
+					"X-Amz-Security-Token": true,
+				},
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"clusterArns": []string{"arn:cluster"},
+				},
+			},
+		},
+	))
+
+	resp, err := ecs.NewFromConfig(m.Config()).ListClusters(context.TODO(), &ecs.ListClustersInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"arn:cluster"}, resp.ClusterArns)
+}
+
+func TestValueMatcherFields(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service:     "ecs",
+				ActionMatch: awsmocker.Glob("List*"),
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"clusterArns": []string{"arn:cluster"},
+					"serviceArns": []string{"arn:service"},
+				},
+			},
+		},
+	))
+
+	client := ecs.NewFromConfig(m.Config())
+
+	clusters, err := client.ListClusters(context.TODO(), &ecs.ListClustersInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"arn:cluster"}, clusters.ClusterArns)
+
+	services, err := client.ListServices(context.TODO(), &ecs.ListServicesInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"arn:service"}, services.ServiceArns)
+}
+
+func TestComposedRequests(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutFailingUnhandledRequests(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: awsmocker.AllOf(
+				&awsmocker.MockedRequest{Service: "ecs"},
+				awsmocker.Not(&awsmocker.MockedRequest{Action: "ListServices"}),
+			),
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"clusterArns": []string{"arn:cluster"},
+				},
+			},
+		},
+	))
+
+	client := ecs.NewFromConfig(m.Config())
+
+	clusters, err := client.ListClusters(context.TODO(), &ecs.ListClustersInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"arn:cluster"}, clusters.ClusterArns)
+
+	_, err = client.ListServices(context.TODO(), &ecs.ListServicesInput{})
+	require.ErrorContains(t, err, "AccessDenied")
+}
+
+func TestJMESPathMatchesFormParams(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "ec2",
+				Action:  "DescribeSubnets",
+				JMESPathMatches: map[string]any{
+					"Filters[?Name=='vpc-id'].Values[0] | [0]": "vpc-123",
+					"SubnetIds[1]": "subnet-2",
+				},
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: &ec2.DescribeSubnetsOutput{
+					Subnets: []ec2types.Subnet{{SubnetId: aws.String("subnet-1")}},
+				},
+			},
+		},
+	))
+
+	resp, err := ec2.NewFromConfig(m.Config()).DescribeSubnets(context.TODO(), &ec2.DescribeSubnetsInput{
+		SubnetIds: []string{"subnet-1", "subnet-2"},
+		Filters: []ec2types.Filter{
+			{Name: aws.String("vpc-id"), Values: []string{"vpc-123"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Subnets, 1)
+}
+
+func TestJSONProtocolVersion(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "dynamodb",
+				Action:  "DescribeTable",
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{"Table": map[string]any{"TableName": "things"}},
+			},
+		},
+		awsmocker.Mock_Failure("dynamodb", "GetItem"),
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "ecs",
+				Action:  "DescribeServices",
+			},
+			Response: awsmocker.MockResponse_Error(400, "ClusterNotFoundException", "Cluster not found."),
+		},
+	))
+
+	client := &http.Client{
+		Transport: &http.Transport{Proxy: m.Proxy()},
+	}
+
+	send := func(target string) (*http.Response, map[string]any) {
+		req, err := http.NewRequest(http.MethodPost, "http://dynamodb.us-east-1.amazonaws.com/", strings.NewReader(`{"TableName":"things"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+		req.Header.Set("X-Amz-Target", target)
+		req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=XXfakekey/20240101/us-east-1/dynamodb/aws4_request")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp, body
+	}
+
+	resp, body := send("DynamoDB_20120810.DescribeTable")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, awsmocker.ContentTypeJSON10, resp.Header.Get("Content-Type"))
+	require.Equal(t, "things", body["Table"].(map[string]any)["TableName"])
+
+	resp, body = send("DynamoDB_20120810.GetItem")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, awsmocker.ContentTypeJSON10, resp.Header.Get("Content-Type"))
+	require.Equal(t, "com.amazonaws.dynamodb.v20120810#AccessDenied", body["__type"])
+
+	// JSON 1.1 errors are mapped to the typed exception by the SDK
+	_, err := ecs.NewFromConfig(m.Config()).DescribeServices(context.TODO(), &ecs.DescribeServicesInput{Services: []string{"svc"}})
+	var notFound *ecstypes.ClusterNotFoundException
+	require.ErrorAs(t, err, &notFound)
+	require.Equal(t, "Cluster not found.", notFound.ErrorMessage())
+}
+
+func TestInputMatcher(t *testing.T) {
+	describeServicesMock := func(cluster, status string) *awsmocker.MockedEndpoint {
+		return &awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				InputMatcher: func(in *ecs.DescribeServicesInput) bool {
+					return aws.ToString(in.Cluster) == cluster
+				},
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"services": []map[string]any{
+						{
+							"serviceName": "someservice",
+							"status":      status,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		describeServicesMock("cluster1", "ACTIVE"),
+		describeServicesMock("cluster2", "DRAINING"),
+	))
+
+	client := ecs.NewFromConfig(m.Config())
+
+	for cluster, status := range map[string]string{"cluster1": "ACTIVE", "cluster2": "DRAINING"} {
+		resp, err := client.DescribeServices(context.TODO(), &ecs.DescribeServicesInput{
+			Services: []string{"someservice"},
+			Cluster:  aws.String(cluster),
+		})
+		require.NoError(t, err)
+		require.Equal(t, status, *resp.Services[0].Status)
+	}
+
+	// other operations are never matched by a typed input matcher
+	tm := NewTestingMock(t)
+	m = awsmocker.Start(tm, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(describeServicesMock("cluster1", "ACTIVE")))
+	_, err := ecs.NewFromConfig(m.Config()).ListClusters(context.TODO(), &ecs.ListClustersInput{})
+	require.Error(t, err)
+	require.True(t, tm.errored)
+}
+
+func TestMockSelection(t *testing.T) {
+	callerMock := func(account string, priority int, req *awsmocker.MockedRequest) *awsmocker.MockedEndpoint {
+		return &awsmocker.MockedEndpoint{
+			Priority: priority,
+			Request:  req,
+			Response: &awsmocker.MockedResponse{
+				Body: sts.GetCallerIdentityOutput{
+					Account: aws.String(account),
+					Arn:     aws.String("arn"),
+					UserId:  aws.String("userid"),
+				},
+			},
+		}
+	}
+
+	t.Run("more specific mock beats the default mocks", func(t *testing.T) {
+		m := awsmocker.Start(t, awsmocker.WithMocks(
+			callerMock("111111111111", 0, &awsmocker.MockedRequest{
+				Service: "sts",
+				Action:  "GetCallerIdentity",
+				Method:  http.MethodPost,
+			}),
+		))
+
+		resp, err := sts.NewFromConfig(m.Config()).GetCallerIdentity(context.TODO(), nil)
+		require.NoError(t, err)
+		require.Equal(t, "111111111111", *resp.Account)
+	})
+
+	t.Run("user mocks win ties with the default mocks", func(t *testing.T) {
+		m := awsmocker.Start(t, awsmocker.WithMocks(
+			callerMock("111111111111", 0, &awsmocker.MockedRequest{Service: "sts", Action: "GetCallerIdentity"}),
+		))
+
+		resp, err := sts.NewFromConfig(m.Config()).GetCallerIdentity(context.TODO(), nil)
+		require.NoError(t, err)
+		require.Equal(t, "111111111111", *resp.Account)
+
+		m.AddMocks(callerMock("222222222222", 0, &awsmocker.MockedRequest{Service: "sts", Action: "GetCallerIdentity"}))
+
+		resp, err = sts.NewFromConfig(m.Config()).GetCallerIdentity(context.TODO(), nil)
+		require.NoError(t, err)
+		require.Equal(t, "111111111111", *resp.Account)
+	})
+
+	t.Run("ties between user mocks keep the original order", func(t *testing.T) {
+		m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+			callerMock("111111111111", 0, &awsmocker.MockedRequest{Service: "sts", Action: "GetCallerIdentity"}),
+			callerMock("222222222222", 0, &awsmocker.MockedRequest{Service: "sts", Action: "GetCallerIdentity"}),
+		))
+
+		resp, err := sts.NewFromConfig(m.Config()).GetCallerIdentity(context.TODO(), nil)
+		require.NoError(t, err)
+		require.Equal(t, "111111111111", *resp.Account)
+	})
+
+	t.Run("priority beats specificity", func(t *testing.T) {
+		m := awsmocker.Start(t, awsmocker.WithMocks(
+			callerMock("111111111111", 0, &awsmocker.MockedRequest{Service: "sts", Action: "GetCallerIdentity", Method: http.MethodPost}),
+			callerMock("222222222222", 10, &awsmocker.MockedRequest{Service: "sts"}),
+		))
+
+		resp, err := sts.NewFromConfig(m.Config()).GetCallerIdentity(context.TODO(), nil)
+		require.NoError(t, err)
+		require.Equal(t, "222222222222", *resp.Account)
+	})
+}
+
+func TestUnmatchedRequestReport(t *testing.T) {
+	tm := NewTestingMock(t)
+
+	m := awsmocker.Start(tm, awsmocker.WithMocks(&awsmocker.MockedEndpoint{
+		Request: &awsmocker.MockedRequest{
+			Service: "ecs",
+			Action:  "DescribeServices",
+			JMESPathMatches: map[string]any{
+				"cluster": "prod",
+			},
+		},
+		Response: &awsmocker.MockedResponse{
+			Body: map[string]any{},
+		},
+	}))
+
+	_, err := ecs.NewFromConfig(m.Config()).DescribeServices(context.TODO(), &ecs.DescribeServicesInput{
+		Services: []string{"someservice"},
+		Cluster:  aws.String("dev"),
+	})
+	require.Error(t, err)
+	require.True(t, tm.errored)
+	require.Len(t, tm.errorMessages, 1)
+
+	msg := tm.errorMessages[0]
+	require.Contains(t, msg, "No matching request mock was found for this request: ecs:DescribeServices")
+	require.Contains(t, msg, "1) MReq<Service=ecs Action=DescribeServices>")
+	require.Contains(t, msg, `PASS Service: "ecs"`)
+	require.Contains(t, msg, `PASS Action: "DescribeServices"`)
+	require.Contains(t, msg, "FAIL JMESPath[cluster]")
+	require.Contains(t, msg, `- expected: "prod"`)
+	require.Contains(t, msg, `+ actual:   "dev"`)
+	require.NotContains(t, msg, "GetCallerIdentity")
+}
+
 func TestStartMockServerForTest(t *testing.T) {
 	// THIS PART REALLY TALKS TO AWS
 	precfg, err := config.LoadDefaultConfig(context.TODO(),
@@ -1,12 +1,14 @@
 package awsmocker
 
 import (
+	"encoding/xml"
 	"fmt"
 	"net/http"
 )
 
 var (
-	// Default Mock for the sts:GetCallerIdentity request
+	// Default Mock for the sts:GetCallerIdentity request.
+	// The account is the one the request came from, so configs from ConfigForAccount get their own account.
 	MockStsGetCallerIdentityValid = &MockedEndpoint{
 		Request: &MockedRequest{
 			Service: "sts",
@@ -14,12 +16,26 @@ var (
 		},
 		Response: &MockedResponse{
 			StatusCode: http.StatusOK,
-			Encoding:   ResponseEncodingXML,
-			Body: map[string]any{
-				"Account": DefaultAccountId,
-				"Arn":     fmt.Sprintf("arn:aws:iam::%s:user/fakeuser", DefaultAccountId),
-				"UserId":  "AKIAI44QH8DHBEXAMPLE",
-			},
+			Body:       stsGetCallerIdentityBody,
 		},
 	}
 )
+
+type stsGetCallerIdentityResponse struct {
+	XMLName   xml.Name `xml:"GetCallerIdentityResponse"`
+	Arn       string   `xml:"GetCallerIdentityResult>Arn"`
+	UserId    string   `xml:"GetCallerIdentityResult>UserId"`
+	Account   string   `xml:"GetCallerIdentityResult>Account"`
+	RequestId string   `xml:"ResponseMetadata>RequestId"`
+}
+
+func stsGetCallerIdentityBody(rr *ReceivedRequest) (string, int, string) {
+	accountId := coalesceString(rr.AccountId, DefaultAccountId)
+
+	return encodeAsXml(&stsGetCallerIdentityResponse{
+		Arn:       fmt.Sprintf("arn:aws:iam::%s:user/fakeuser", accountId),
+		UserId:    "AKIAI44QH8DHBEXAMPLE",
+		Account:   accountId,
+		RequestId: generateRequestId(),
+	}), http.StatusOK, ContentTypeXML
+}
@@ -31,6 +31,12 @@ type mockerOptions struct {
 
 	AwsConfigOptions []AwsLoadOptionsFunc
 
+	// Groups of mocks that must be matched in order
+	OrderedMocks [][]*MockedEndpoint
+
+	// What to do with mocks that never matched a request
+	unusedMocks unusedMocksMode
+
 	noMiddleware bool
 }
 
@@ -40,6 +46,10 @@ var defaultMocks = []*MockedEndpoint{
 	MockStsGetCallerIdentityValid,
 }
 
+func isDefaultMock(mock *MockedEndpoint) bool {
+	return slices.Contains(defaultMocks, mock)
+}
+
 func newOptions() *mockerOptions {
 	return &mockerOptions{
 		Timeout: 5 * time.Second,
@@ -51,7 +61,7 @@ func newOptions() *mockerOptions {
 func WithoutDefaultMocks() MockerOptionFunc {
 	return func(o *mockerOptions) {
 		o.Mocks = slices.DeleteFunc(o.Mocks, func(m *MockedEndpoint) bool {
-			return slices.Contains(defaultMocks, m)
+			return isDefaultMock(m)
 		})
 	}
 }
@@ -88,6 +98,22 @@ func WithoutMiddleware() MockerOptionFunc {
 	}
 }
 
+// Fail the test if any mocks were never matched by a request.
+// Default mocks, and mocks that are marked Optional are not reported.
+func WithFailOnUnusedMocks() MockerOptionFunc {
+	return func(o *mockerOptions) {
+		o.unusedMocks = unusedMocksFail
+	}
+}
+
+// Log a warning for any mocks that were never matched by a request, without failing the test.
+// Default mocks, and mocks that are marked Optional are not reported.
+func WithWarnOnUnusedMocks() MockerOptionFunc {
+	return func(o *mockerOptions) {
+		o.unusedMocks = unusedMocksWarn
+	}
+}
+
 // Add mocks for the EC2 Instance Metadata Service
 // These are not exhaustive, so if you have a special need you will have to add it.
 func WithEC2Metadata(opts ...IMDSMockOptionFunc) MockerOptionFunc {
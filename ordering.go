@@ -0,0 +1,66 @@
+package awsmocker
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+)
+
+// Add mocks that are expected to be matched in the order they are given.
+//
+// Every mock in the group must have been matched before the next one is, and once a mock
+// has been matched, the ones before it must not match again. This is checked when the test finishes.
+//
+// The mocks do not need to be passed to [WithMocks] as well.
+func WithMocksInOrder(mocks ...*MockedEndpoint) MockerOptionFunc {
+	return func(o *mockerOptions) {
+		o.Mocks = append(o.Mocks, mocks...)
+		o.OrderedMocks = append(o.OrderedMocks, mocks)
+	}
+}
+
+// reports any ordered mock groups that were called out of order
+func (m *mocker) verifyOrder() {
+	if len(m.orderedMocks) == 0 {
+		return
+	}
+
+	requests := m.journal.all()
+
+	for _, group := range m.orderedMocks {
+		highest := -1
+		for _, rr := range requests {
+			idx := slices.Index(group, rr.MatchedEndpoint)
+			if idx < 0 {
+				continue
+			}
+
+			if idx > highest+1 || idx < highest {
+				m.t.Errorf("%s", describeOrderViolation(group, requests))
+				break
+			}
+
+			highest = max(highest, idx)
+		}
+	}
+}
+
+func describeOrderViolation(group []*MockedEndpoint, requests []*ReceivedRequest) string {
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintln(buf, "Mocks were called out of order. Expected order:")
+	for i, mock := range group {
+		fmt.Fprintf(buf, "  %d) %s\n", i+1, mock.Request.Inspect())
+	}
+
+	fmt.Fprintln(buf, "Actual calls:")
+	for i, rr := range requests {
+		if idx := slices.Index(group, rr.MatchedEndpoint); idx >= 0 {
+			fmt.Fprintf(buf, "  %d) %s (expected #%d)\n", i+1, rr.Inspect(), idx+1)
+		} else {
+			fmt.Fprintf(buf, "  %d) %s\n", i+1, rr.Inspect())
+		}
+	}
+
+	return buf.String()
+}
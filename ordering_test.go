@@ -0,0 +1,88 @@
+package awsmocker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestWithMocksInOrder(t *testing.T) {
+	emptyMock := func(action string) *awsmocker.MockedEndpoint {
+		return awsmocker.NewSimpleMockedEndpoint("ecs", action, map[string]any{})
+	}
+
+	calls := map[string]func(*ecs.Client) error{
+		"CreateCluster": func(c *ecs.Client) error {
+			_, err := c.CreateCluster(context.TODO(), &ecs.CreateClusterInput{})
+			return err
+		},
+		"CreateService": func(c *ecs.Client) error {
+			_, err := c.CreateService(context.TODO(), &ecs.CreateServiceInput{ServiceName: aws.String("svc")})
+			return err
+		},
+		"DeleteService": func(c *ecs.Client) error {
+			_, err := c.DeleteService(context.TODO(), &ecs.DeleteServiceInput{Service: aws.String("svc")})
+			return err
+		},
+		"ListClusters": func(c *ecs.Client) error {
+			_, err := c.ListClusters(context.TODO(), &ecs.ListClustersInput{})
+			return err
+		},
+	}
+
+	tables := []struct {
+		name     string
+		calls    []string
+		violated bool
+	}{
+		{"InOrder", []string{"CreateCluster", "CreateService", "DeleteService"}, false},
+		{"RepeatsAndOtherCalls", []string{"CreateCluster", "ListClusters", "CreateCluster", "CreateService", "CreateService", "DeleteService"}, false},
+		{"Swapped", []string{"CreateCluster", "DeleteService", "CreateService"}, true},
+		{"EarlierAfterLater", []string{"CreateCluster", "CreateService", "CreateCluster", "DeleteService"}, true},
+		{"SkippedFirst", []string{"CreateService", "DeleteService"}, true},
+	}
+
+	for _, table := range tables {
+		var tm *TestingMock
+
+		t.Run(table.name, func(t *testing.T) {
+			tm = NewTestingMock(t)
+
+			m := awsmocker.Start(tm,
+				awsmocker.WithMocks(emptyMock("ListClusters")),
+				awsmocker.WithMocksInOrder(emptyMock("CreateCluster"), emptyMock("CreateService"), emptyMock("DeleteService")),
+			)
+			client := ecs.NewFromConfig(m.Config())
+
+			for _, call := range table.calls {
+				require.NoError(t, calls[call](client))
+			}
+		})
+
+		if !table.violated {
+			require.Falsef(t, tm.errored, "%s: unexpected errors: %v", table.name, tm.errorMessages)
+			continue
+		}
+
+		require.Truef(t, tm.errored, "%s: expected an error", table.name)
+		msg := tm.errorMessages[0]
+		require.Contains(t, msg, "Mocks were called out of order. Expected order:")
+		require.Contains(t, msg, "1) MReq<Service=ecs Action=CreateCluster>")
+		require.Contains(t, msg, "3) MReq<Service=ecs Action=DeleteService>")
+		require.Contains(t, msg, "Actual calls:")
+		require.Contains(t, msg, "1) ecs:"+table.calls[0])
+	}
+}
+
+func TestWithMocksInOrder_NotDuplicated(t *testing.T) {
+	mock := awsmocker.NewSimpleMockedEndpoint("ecs", "ListClusters", map[string]any{}).Times(1)
+
+	m := awsmocker.Start(t, awsmocker.WithMocks(mock), awsmocker.WithMocksInOrder(mock))
+
+	_, err := ecs.NewFromConfig(m.Config()).ListClusters(context.TODO(), &ecs.ListClustersInput{})
+	require.NoError(t, err)
+}
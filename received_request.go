@@ -7,12 +7,17 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/clbanning/mxj"
 )
 
 var (
 	credExtractRegexp = regexp.MustCompile(`Credential=(\S+)\b`)
 	jsonRegexp        = regexp.MustCompile(`json`)
+	awsJsonRegexp     = regexp.MustCompile(`^application/x-amz-json-1\.[01]\b`)
+	xmlRegexp         = regexp.MustCompile(`xml`)
 )
 
 type ReceivedRequest struct {
@@ -22,6 +27,10 @@ type ReceivedRequest struct {
 	Service string
 	Region  string
 
+	// The account the request was made from. This is only known for requests signed with
+	// credentials from the mocker config, see [MockerInfo.ConfigForAccount]
+	AccountId string
+
 	Hostname string
 	Path     string
 
@@ -35,9 +44,36 @@ type ReceivedRequest struct {
 	// If the request was a JSON request, then this will be the parsed JSON
 	JsonPayload any
 
+	// If the request used the RPCv2 CBOR protocol, then this will be the decoded body, in the same
+	// form as JsonPayload. Blobs are base64 strings and timestamps are epoch seconds.
+	CborPayload any
+
+	// If the request had an XML body, then this will be the parsed XML. The root element is kept,
+	// attributes are prefixed with "-" and all values are strings. Repeated elements become lists.
+	// Items of known list elements (Changes>Change, TagSet>Tag, CompleteMultipartUpload>Part) are always lists,
+	// but any other element that appears once is not.
+	XmlPayload map[string]any
+
+	// If the request used the query or EC2 protocols, then this will be the form params
+	// rebuilt into a nested structure. See JMESPathMatches
+	FormPayload map[string]any
+
+	// The mock that answered this request. This is nil if no mock matched it
+	MatchedEndpoint *MockedEndpoint
+
+	// The typed SDK input (e.g. *sqs.SendMessageInput) for the request.
+	// This is nil if the request did not come from a client using the mocker config
+	Input any
+
+	// The HTTP status code of the response the mocker sent back
+	StatusCode int
+
 	// TBA: maybe in the future we'll add invalid request flagging, for now allow all types
 	// invalid bool
 
+	// The AWS JSON protocol version of the request (application/x-amz-json-1.0), which is used for the response
+	awsJsonContentType string
+
 	// internal reference to the mocker parent
 	mocker *mocker
 }
@@ -74,6 +110,7 @@ func newReceivedRequest(req *http.Request) *ReceivedRequest {
 	reqContentType := req.Header.Get("content-type")
 	if jsonRegexp.MatchString(reqContentType) {
 		recvreq.AssumedResponseType = ContentTypeJSON
+		recvreq.awsJsonContentType = awsJsonRegexp.FindString(reqContentType)
 
 		if len(bodyBytes) > 0 {
 			var jsonData any
@@ -84,12 +121,34 @@ func newReceivedRequest(req *http.Request) *ReceivedRequest {
 
 	}
 
+	if req.Header.Get("smithy-protocol") == smithyProtocolRpcV2Cbor {
+		recvreq.AssumedResponseType = ContentTypeCBOR
+
+		if matches := rpcV2CborPathRegexp.FindStringSubmatch(req.URL.Path); matches != nil {
+			recvreq.Action = matches[2]
+		}
+
+		if len(bodyBytes) > 0 {
+			if cborData, err := decodeCborPayload(bodyBytes); err == nil {
+				recvreq.CborPayload = cborData
+			}
+		}
+	}
+
+	if xmlRegexp.MatchString(reqContentType) || (reqContentType == "" && bytes.HasPrefix(bytes.TrimSpace(bodyBytes), []byte("<"))) {
+		if xmlData, err := mxj.NewMapXml(bodyBytes); err == nil && len(xmlData) > 0 {
+			normalizeXmlLists(map[string]any(xmlData))
+			recvreq.XmlPayload = xmlData
+		}
+	}
+
 	if authHeader := req.Header.Get("authorization"); authHeader != "" {
 		matches := credExtractRegexp.FindStringSubmatch(authHeader)
 		if len(matches) > 1 {
 			// 0       1         2      3    4
 			// fake/20221030/us-east-1/ecs/aws4_request
 			parts := strings.Split(matches[1], "/")
+			recvreq.AccountId = accountIdFromAccessKey(parts[0])
 			recvreq.Region = parts[2]
 			recvreq.Service = parts[3]
 		}
@@ -108,6 +167,10 @@ func newReceivedRequest(req *http.Request) *ReceivedRequest {
 		recvreq.Action = req.PostForm.Get("Action")
 	}
 
+	if recvreq.JsonPayload == nil && req.Form.Has("Action") {
+		recvreq.FormPayload = decodeFormPayload(req.Form)
+	}
+
 	if recvreq.AssumedResponseType == ContentTypeText && recvreq.Action != "" && recvreq.Service != "" && reqContentType == "application/x-www-form-urlencoded" {
 		recvreq.AssumedResponseType = ContentTypeXML
 	}
@@ -129,6 +192,79 @@ func newReceivedRequest(req *http.Request) *ReceivedRequest {
 	return recvreq
 }
 
+// The structured payload of the request, used for JMESPath matching
+func (rr *ReceivedRequest) payload() any {
+	if rr.JsonPayload != nil {
+		return rr.JsonPayload
+	}
+
+	if rr.CborPayload != nil {
+		return rr.CborPayload
+	}
+
+	if rr.FormPayload != nil {
+		return rr.FormPayload
+	}
+
+	if rr.XmlPayload != nil {
+		return rr.XmlPayload
+	}
+
+	return nil
+}
+
+// The content type for JSON responses, using the same AWS JSON protocol version as the request
+func (rr *ReceivedRequest) jsonContentType() string {
+	if rr.awsJsonContentType != "" {
+		return rr.awsJsonContentType
+	}
+	return ContentTypeJSON
+}
+
+// Same as inferContentType, but JSON bodies use the protocol version of the request
+func (rr *ReceivedRequest) inferContentType(value string) string {
+	if contentType := inferContentType(value); contentType != ContentTypeJSON {
+		return contentType
+	}
+	return rr.jsonContentType()
+}
+
+// the Smithy namespace of the error shapes for services where AWS qualifies the __type of an error
+var jsonErrorNamespaces = map[string]string{
+	"dynamodb": "com.amazonaws.dynamodb.v20120810",
+	"sqs":      "com.amazonaws.sqs",
+}
+
+// The __type of an error code. Codes are only qualified for services with a known namespace
+// (com.amazonaws.dynamodb.v20120810#ResourceNotFoundException), or if the mock already qualified it.
+func (rr *ReceivedRequest) jsonErrorType(code string) string {
+	ns, ok := jsonErrorNamespaces[rr.Service]
+	if !ok || strings.Contains(code, "#") {
+		return code
+	}
+	return ns + "#" + code
+}
+
+// Returns the typed SDK input that was captured by the mocker middleware
+func (rr *ReceivedRequest) sdkParameters() (any, bool) {
+	if rr.mocker == nil || rr.HttpRequest == nil {
+		return nil, false
+	}
+
+	reqId, err := strconv.ParseUint(rr.HttpRequest.Header.Get(mwHeaderRequestId), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	entry, ok := rr.mocker.requestLog.Load(reqId)
+	if !ok {
+		return nil, false
+	}
+
+	params := entry.(mwDBEntry).Parameters
+	return params, params != nil
+}
+
 func (r *ReceivedRequest) DebugDump() {
 	// var buf *bytes.Buffer
 	buf := new(bytes.Buffer)
@@ -49,5 +49,6 @@ func TestReceivedRequest_DebugDump(t *testing.T) {
 	require.Contains(t, debugStr, "ecs.us-east-1.amazonaws.com")
 	require.Contains(t, debugStr, "service=ecs")
 	require.Contains(t, debugStr, "ListClusters")
+	require.Contains(t, debugStr, "AWSMOCKER SELECTED MOCK: MReq<Service=ecs Action=ListClusters> (priority=0 specificity=2)")
 
 }
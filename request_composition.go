@@ -0,0 +1,147 @@
+package awsmocker
+
+import (
+	"fmt"
+	"strings"
+)
+
+type compositionOp string
+
+const (
+	compositionAnyOf compositionOp = "AnyOf"
+	compositionAllOf compositionOp = "AllOf"
+	compositionNot   compositionOp = "Not"
+)
+
+// a set of requests combined with a boolean operator
+type requestComposition struct {
+	op       compositionOp
+	children []*MockedRequest
+}
+
+// Matches when any of the requests match.
+//
+//	AnyOf(
+//		&MockedRequest{Service: "sqs", Action: "SendMessage"},
+//		&MockedRequest{Service: "sqs", Action: "SendMessageBatch"},
+//	)
+//
+// The returned request can have other fields set on it, and they must match as well.
+func AnyOf(requests ...*MockedRequest) *MockedRequest {
+	return newComposedRequest(compositionAnyOf, requests)
+}
+
+// Matches when every one of the requests match
+func AllOf(requests ...*MockedRequest) *MockedRequest {
+	return newComposedRequest(compositionAllOf, requests)
+}
+
+// Matches when the request does not match.
+//
+//	AllOf(
+//		&MockedRequest{Service: "ec2"},
+//		Not(&MockedRequest{Action: "DescribeRegions"}),
+//	)
+func Not(request *MockedRequest) *MockedRequest {
+	return newComposedRequest(compositionNot, []*MockedRequest{request})
+}
+
+func newComposedRequest(op compositionOp, requests []*MockedRequest) *MockedRequest {
+	children := make([]*MockedRequest, 0, len(requests))
+	for _, req := range requests {
+		if req != nil {
+			children = append(children, req)
+		}
+	}
+
+	if len(children) == 0 {
+		panic(fmt.Sprintf("%s needs at least one request", op))
+	}
+
+	return &MockedRequest{
+		composition: &requestComposition{
+			op:       op,
+			children: children,
+		},
+	}
+}
+
+func (rc *requestComposition) prep() {
+	for _, child := range rc.children {
+		child.prep()
+	}
+}
+
+func (rc *requestComposition) validate() error {
+	for i, child := range rc.children {
+		if err := child.validate(); err != nil {
+			return fmt.Errorf("%s[%d].%w", rc.op, i, err)
+		}
+	}
+	return nil
+}
+
+func (rc *requestComposition) specificity() int {
+	switch rc.op {
+	case compositionAllOf:
+		score := 0
+		for _, child := range rc.children {
+			score += child.specificity()
+		}
+		return score
+
+	case compositionAnyOf:
+		// only as specific as the loosest option
+		score := rc.children[0].specificity()
+		for _, child := range rc.children[1:] {
+			score = min(score, child.specificity())
+		}
+		return score
+
+	default:
+		return 1
+	}
+}
+
+func (rc *requestComposition) addChecks(c *requestChecks, rr *ReceivedRequest) bool {
+	switch rc.op {
+	case compositionAllOf:
+		for i, child := range rc.children {
+			if !c.merge(fmt.Sprintf("AllOf[%d].", i+1), child.checkRequest(rr, c.failFast)) {
+				return false
+			}
+		}
+		return true
+
+	case compositionAnyOf:
+		misses := make([]string, 0, len(rc.children))
+		for i, child := range rc.children {
+			childChecks := child.checkRequest(rr, true)
+			if !childChecks.failed {
+				return c.add("AnyOf", true, fmt.Sprintf("one of %d requests", len(rc.children)), fmt.Sprintf("matched [%d]", i+1))
+			}
+			misses = append(misses, fmt.Sprintf("[%d] %s", i+1, childChecks.firstFailure()))
+		}
+		return c.add("AnyOf", false, fmt.Sprintf("one of %d requests", len(rc.children)), strings.Join(misses, "; "))
+
+	default:
+		child := rc.children[0]
+		childChecks := child.checkRequest(rr, true)
+		actual := "did not match"
+		if !childChecks.failed {
+			actual = "matched"
+		}
+		return c.add("Not", childChecks.failed, "not "+strings.ReplaceAll(child.Inspect(), "\n", " "), actual)
+	}
+}
+
+// renders the composition as an indented tree
+func (rc *requestComposition) inspect(indent string) string {
+	buf := new(strings.Builder)
+	buf.WriteString(string(rc.op) + "(")
+	for _, child := range rc.children {
+		buf.WriteString("\n" + indent + "  " + child.inspect(indent+"  "))
+	}
+	buf.WriteString("\n" + indent + ")")
+	return buf.String()
+}
@@ -0,0 +1,149 @@
+package awsmocker
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestComposition(t *testing.T) {
+	sendMessage := &MockedRequest{Service: "sqs", Action: "SendMessage"}
+	sendBatch := &MockedRequest{Service: "sqs", Action: "SendMessageBatch"}
+
+	newRR := func(service, action string) *ReceivedRequest {
+		return &ReceivedRequest{Service: service, Action: action}
+	}
+
+	tables := []struct {
+		name    string
+		mr      *MockedRequest
+		matches []*ReceivedRequest
+		misses  []*ReceivedRequest
+	}{
+		{
+			name:    "AnyOf",
+			mr:      AnyOf(sendMessage, sendBatch),
+			matches: []*ReceivedRequest{newRR("sqs", "SendMessage"), newRR("sqs", "SendMessageBatch")},
+			misses:  []*ReceivedRequest{newRR("sqs", "ReceiveMessage"), newRR("sns", "SendMessage")},
+		},
+		{
+			name:    "AllOf",
+			mr:      AllOf(&MockedRequest{Service: "ec2"}, &MockedRequest{ActionMatch: Glob("Describe*")}),
+			matches: []*ReceivedRequest{newRR("ec2", "DescribeRegions")},
+			misses:  []*ReceivedRequest{newRR("ec2", "RunInstances"), newRR("ecs", "DescribeClusters")},
+		},
+		{
+			name:    "Not",
+			mr:      AllOf(&MockedRequest{Service: "ec2"}, Not(&MockedRequest{Action: "DescribeRegions"})),
+			matches: []*ReceivedRequest{newRR("ec2", "DescribeInstances"), newRR("ec2", "RunInstances")},
+			misses:  []*ReceivedRequest{newRR("ec2", "DescribeRegions"), newRR("ecs", "ListClusters")},
+		},
+		{
+			name: "WithOwnFields",
+			mr: func() *MockedRequest {
+				mr := Not(&MockedRequest{Action: "DescribeRegions"})
+				mr.Service = "ec2"
+				return mr
+			}(),
+			matches: []*ReceivedRequest{newRR("ec2", "DescribeInstances")},
+			misses:  []*ReceivedRequest{newRR("ec2", "DescribeRegions"), newRR("ecs", "ListClusters")},
+		},
+		{
+			name:    "Nested",
+			mr:      AnyOf(AllOf(sendMessage, Not(&MockedRequest{Hostname: "localhost"})), sendBatch),
+			matches: []*ReceivedRequest{newRR("sqs", "SendMessage"), newRR("sqs", "SendMessageBatch")},
+			misses:  []*ReceivedRequest{{Service: "sqs", Action: "SendMessage", Hostname: "localhost"}},
+		},
+	}
+
+	for _, table := range tables {
+		table.mr.prep()
+		for _, rr := range table.matches {
+			require.Truef(t, table.mr.matchRequest(rr), "%s: should match %s", table.name, rr.Inspect())
+		}
+		for _, rr := range table.misses {
+			require.Falsef(t, table.mr.matchRequest(rr), "%s: should not match %s", table.name, rr.Inspect())
+		}
+	}
+
+	require.Panics(t, func() { AnyOf() })
+}
+
+func TestRequestComposition_Strict(t *testing.T) {
+	mr := AnyOf(
+		&MockedRequest{Strict: true, Params: url.Values{"Action": []string{"ListUsers"}}},
+		&MockedRequest{Strict: true, Params: url.Values{"Action": []string{"ListRoles"}, "MaxItems": []string{"5"}}},
+	)
+
+	newRR := func(form url.Values) *ReceivedRequest {
+		return &ReceivedRequest{HttpRequest: &http.Request{Form: form}}
+	}
+
+	require.True(t, mr.matchRequest(newRR(url.Values{"Action": []string{"ListUsers"}})))
+	require.True(t, mr.matchRequest(newRR(url.Values{"Action": []string{"ListRoles"}, "MaxItems": []string{"5"}})))
+	require.False(t, mr.matchRequest(newRR(url.Values{"Action": []string{"ListUsers"}, "MaxItems": []string{"5"}})))
+	require.False(t, mr.matchRequest(newRR(url.Values{"Action": []string{"ListRoles"}})))
+}
+
+func TestRequestComposition_specificity(t *testing.T) {
+	require.Equal(t, 2, AnyOf(
+		&MockedRequest{Service: "sqs", Action: "SendMessage"},
+		&MockedRequest{Service: "sqs", Action: "SendMessageBatch", Method: http.MethodPost},
+	).specificity())
+
+	require.Equal(t, 3, AllOf(
+		&MockedRequest{Service: "ec2"},
+		&MockedRequest{Action: "RunInstances", Method: http.MethodPost},
+	).specificity())
+
+	require.Equal(t, 2, func() *MockedRequest {
+		mr := Not(&MockedRequest{Action: "DescribeRegions"})
+		mr.Service = "ec2"
+		return mr
+	}().specificity())
+}
+
+func TestRequestComposition_Inspect(t *testing.T) {
+	mr := AnyOf(
+		&MockedRequest{Service: "sqs", Action: "SendMessage"},
+		AllOf(
+			&MockedRequest{Service: "ec2"},
+			Not(&MockedRequest{Action: "DescribeRegions"}),
+		),
+	)
+
+	require.Equal(t, `MReq<AnyOf(
+  MReq<Service=sqs Action=SendMessage>
+  MReq<AllOf(
+    MReq<Service=ec2>
+    MReq<Not(
+      MReq<Action=DescribeRegions>
+    )>
+  )>
+)>`, mr.Inspect())
+}
+
+func TestRequestComposition_checks(t *testing.T) {
+	rr := &ReceivedRequest{Service: "ec2", Action: "DescribeRegions"}
+
+	anyOf := AnyOf(
+		&MockedRequest{Service: "sqs", Action: "SendMessage"},
+		&MockedRequest{Service: "ec2", Action: "RunInstances"},
+	).checkRequest(rr, false)
+	require.Equal(t, []matchCheck{{
+		field:    "AnyOf",
+		expected: "one of 2 requests",
+		actual:   `[1] Service: expected "sqs", got "ec2"; [2] Action: expected "RunInstances", got "DescribeRegions"`,
+	}}, anyOf.checks)
+
+	allOf := AllOf(
+		&MockedRequest{Service: "ec2"},
+		Not(&MockedRequest{Action: "DescribeRegions"}),
+	).checkRequest(rr, false)
+	require.Equal(t, []matchCheck{
+		{field: "AllOf[1].Service", passed: true, expected: `"ec2"`, actual: `"ec2"`},
+		{field: "AllOf[2].Not", expected: "not MReq<Action=DescribeRegions>", actual: "matched"},
+	}, allOf.checks)
+}
@@ -0,0 +1,102 @@
+package awsmocker
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aws/smithy-go/encoding/cbor"
+)
+
+const ContentTypeCBOR = "application/cbor"
+
+func (m *MockedResponse) encodeCbor(rr *ReceivedRequest, rBody reflect.Value) *httpResponse {
+	value, err := toCborValue(rBody)
+	if err != nil {
+		return generateErrorStruct(0, "BadMockBody", "Could not encode CBOR body: %s", err).getResponse(rr)
+	}
+
+	if value == nil {
+		value = cbor.Map{}
+	}
+
+	return &httpResponse{
+		bodyRaw:     cbor.Encode(value),
+		StatusCode:  m.StatusCode,
+		contentType: coalesceString(m.ContentType, ContentTypeCBOR),
+		extraHeaders: map[string]string{
+			"Smithy-Protocol": smithyProtocolRpcV2Cbor,
+		},
+	}
+}
+
+// Converts a map, struct or SDK output type to a CBOR value. Nil values return nil, and
+// are left out of maps
+func toCborValue(value reflect.Value) (cbor.Value, error) {
+	value = derefValue(value)
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	if t, ok := value.Interface().(time.Time); ok {
+		return &cbor.Tag{ID: cborTagEpochTime, Value: cbor.Float64(float64(t.UnixMilli()) / 1000)}, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map:
+		result := make(cbor.Map)
+		err := forEachMember(value, func(field reflect.StructField, member reflect.Value) error {
+			item, err := toCborValue(member)
+			if err != nil {
+				return fmt.Errorf("%s: %w", field.Name, err)
+			}
+			if item != nil {
+				result[field.Name] = item
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	case reflect.Slice, reflect.Array:
+		if value.Type() == byteArrayType {
+			return cbor.Slice(value.Bytes()), nil
+		}
+
+		result := make(cbor.List, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, err := toCborValue(value.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			if item == nil {
+				item = &cbor.Nil{}
+			}
+			result = append(result, item)
+		}
+		return result, nil
+
+	case reflect.String:
+		return cbor.String(value.String()), nil
+
+	case reflect.Bool:
+		return cbor.Bool(value.Bool()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := value.Int(); n < 0 {
+			return cbor.NegInt(uint64(-n)), nil
+		}
+		return cbor.Uint(uint64(value.Int())), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cbor.Uint(value.Uint()), nil
+
+	case reflect.Float32, reflect.Float64:
+		return cbor.Float64(value.Float()), nil
+
+	default:
+		return nil, fmt.Errorf("cannot encode a kind=%v", value.Kind())
+	}
+}
@@ -0,0 +1,167 @@
+package awsmocker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/clbanning/mxj"
+)
+
+const ec2Namespace = "http://ec2.amazonaws.com/doc/2016-11-15/"
+
+// EC2 member names that do not follow the lowerCamel/Set rules. The key is either
+// the member name, or the struct type and member name for names that depend on the parent shape
+var ec2MemberNames = map[string]string{
+	"AvailabilityZones":   "availabilityZoneInfo",
+	"Addresses":           "addressesSet",
+	"BlockDeviceMappings": "blockDeviceMapping",
+	"Images":              "imagesSet",
+	"Instances":           "instancesSet",
+	"IpPermissions":       "ipPermissions",
+	"IpPermissionsEgress": "ipPermissionsEgress",
+	"IpRanges":            "ipRanges",
+	"Ipv6Ranges":          "ipv6Ranges",
+	"KeyPairs":            "keySet",
+	"PrefixListIds":       "prefixListIds",
+	"PrivateIpAddresses":  "privateIpAddressesSet",
+	"Regions":             "regionInfo",
+	"SecurityGroups":      "securityGroupInfo",
+	"UserIdGroupPairs":    "groups",
+
+	"Instance.PublicDnsName":   "dnsName",
+	"Instance.PublicIpAddress": "ipAddress",
+	"Instance.SecurityGroups":  "groupSet",
+	"Instance.State":           "instanceState",
+	"Reservation.Groups":       "groupSet",
+	"Volume.State":             "status",
+}
+
+// Encodes a body using the EC2 query protocol. Unlike the query protocol used by other services,
+// there is no Result element or ResponseMetadata, and lists are written as item elements
+func (m *MockedResponse) encodeEC2Query(rr *ReceivedRequest, rBody reflect.Value, actionName string) *httpResponse {
+	body, ok := toEC2Value(rBody).(map[string]any)
+	if !ok {
+		return generateErrorStruct(0, "BadMockBody", "an EC2 body must be a map or struct, got kind=%v", rBody.Kind()).getResponse(rr)
+	}
+
+	if _, ok := body["requestId"]; !ok {
+		body["requestId"] = generateRequestId()
+	}
+	body["-xmlns"] = ec2Namespace
+
+	rootTag := coalesceString(m.RootTag, actionName+"Response")
+
+	xmlout, err := mxj.AnyXmlIndent(body, "", "  ", rootTag)
+	if err != nil {
+		return generateErrorStruct(0, "BadMockBody", "Could not serialize body to XML: %s", err).getResponse(rr)
+	}
+
+	return &httpResponse{
+		bodyRaw:     xmlout,
+		StatusCode:  m.StatusCode,
+		contentType: ContentTypeXML,
+	}
+}
+
+// converts a value to the form mxj expects, renaming members to their EC2 wire names
+func toEC2Value(value reflect.Value) any {
+	value = derefValue(value)
+	if !value.IsValid() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if t, ok := value.Interface().(time.Time); ok {
+			return t.UTC().Format("2006-01-02T15:04:05.000Z")
+		}
+
+		fallthrough
+
+	case reflect.Map:
+		// struct member names can depend on the parent shape, maps are not a shape
+		parent := ""
+		if value.Kind() == reflect.Struct {
+			parent = value.Type().Name()
+		}
+
+		result := make(map[string]any)
+		_ = forEachMember(value, func(field reflect.StructField, member reflect.Value) error {
+			addEC2Member(result, parent, field.Name, member)
+			return nil
+		})
+		return result
+
+	case reflect.Slice, reflect.Array:
+		if value.Type() == byteArrayType {
+			return toRestXMLValue(value)
+		}
+
+		items := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if item := toEC2Value(value.Index(i)); item != nil {
+				items = append(items, item)
+			}
+		}
+		return map[string]any{"item": items}
+
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+func addEC2Member(dst map[string]any, parent, name string, value reflect.Value) {
+	value = derefValue(value)
+	if !value.IsValid() {
+		return
+	}
+
+	isList := (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Type() != byteArrayType
+	dst[ec2MemberName(parent, name, isList)] = toEC2Value(value)
+}
+
+// The name EC2 uses for a member. Names that already start with a lowercase letter are assumed
+// to be wire names, so maps can be written either way
+func ec2MemberName(parent, name string, isList bool) string {
+	if name == "" || !unicode.IsUpper(rune(name[0])) {
+		return name
+	}
+
+	if wire, ok := ec2MemberNames[parent+"."+name]; ok {
+		return wire
+	}
+
+	if wire, ok := ec2MemberNames[name]; ok {
+		return wire
+	}
+
+	wire := strings.ToLower(name[:1]) + name[1:]
+	if !isList || strings.HasSuffix(wire, "Set") {
+		return wire
+	}
+
+	switch {
+	case strings.HasSuffix(wire, "ies"):
+		wire = strings.TrimSuffix(wire, "ies") + "y"
+	case strings.HasSuffix(wire, "sses"):
+		wire = strings.TrimSuffix(wire, "es")
+	case strings.HasSuffix(wire, "s"):
+		wire = strings.TrimSuffix(wire, "s")
+	}
+
+	return wire + "Set"
+}
+
+// the error format used by EC2, which differs from the query protocol
+type ec2ErrorResponse struct {
+	XMLName xml.Name `xml:"Response"`
+
+	Code    string `xml:"Errors>Error>Code"`
+	Message string `xml:"Errors>Error>Message"`
+
+	RequestId string `xml:"RequestID"`
+}
@@ -0,0 +1,109 @@
+package awsmocker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestEC2QueryResponses(t *testing.T) {
+	launchTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "ec2",
+				Action:  "DescribeSubnets",
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"Subnets": []map[string]any{
+						{"SubnetId": "subnet-1", "VpcId": "vpc-1", "AvailableIpAddressCount": 250},
+						{"subnetId": "subnet-2", "vpcId": "vpc-1", "tagSet": []map[string]any{
+							{"key": "Name", "value": "private"},
+						}},
+					},
+				},
+			},
+		},
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "ec2",
+				Action:  "DescribeVpcs",
+			},
+			Response: awsmocker.MockResponse_Error(400, "InvalidVpcID.NotFound", "The vpc ID 'vpc-nope' does not exist"),
+		},
+	))
+
+	client := ec2.NewFromConfig(m.Config())
+
+	subnetResp, err := client.DescribeSubnets(context.TODO(), &ec2.DescribeSubnetsInput{})
+	require.NoError(t, err)
+	require.Len(t, subnetResp.Subnets, 2)
+	require.Equal(t, "subnet-1", *subnetResp.Subnets[0].SubnetId)
+	require.EqualValues(t, 250, *subnetResp.Subnets[0].AvailableIpAddressCount)
+	require.Equal(t, "vpc-1", *subnetResp.Subnets[1].VpcId)
+	require.Equal(t, "private", *subnetResp.Subnets[1].Tags[0].Value)
+
+	_, err = client.DescribeVpcs(context.TODO(), &ec2.DescribeVpcsInput{VpcIds: []string{"vpc-nope"}})
+	require.Error(t, err)
+	var apiErr smithy.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, "InvalidVpcID.NotFound", apiErr.ErrorCode())
+	require.Equal(t, "The vpc ID 'vpc-nope' does not exist", apiErr.ErrorMessage())
+
+	t.Run("sdk output without middleware", func(t *testing.T) {
+		m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutMiddleware(), awsmocker.WithMocks(
+			&awsmocker.MockedEndpoint{
+				Request: &awsmocker.MockedRequest{
+					Service: "ec2",
+					Action:  "DescribeInstances",
+				},
+				Response: &awsmocker.MockedResponse{
+					Body: &ec2.DescribeInstancesOutput{
+						Reservations: []ec2types.Reservation{
+							{
+								ReservationId: aws.String("r-1"),
+								Instances: []ec2types.Instance{
+									{
+										InstanceId:      aws.String("i-1"),
+										InstanceType:    ec2types.InstanceTypeT3Micro,
+										LaunchTime:      aws.Time(launchTime),
+										PublicIpAddress: aws.String("203.0.113.10"),
+										State:           &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning, Code: aws.Int32(16)},
+										SecurityGroups:  []ec2types.GroupIdentifier{{GroupId: aws.String("sg-1")}},
+										Tags:            []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("web")}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		))
+
+		client := ec2.NewFromConfig(m.Config())
+
+		resp, err := client.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{})
+		require.NoError(t, err)
+		require.Len(t, resp.Reservations, 1)
+		require.Equal(t, "r-1", *resp.Reservations[0].ReservationId)
+
+		inst := resp.Reservations[0].Instances[0]
+		require.Equal(t, "i-1", *inst.InstanceId)
+		require.Equal(t, ec2types.InstanceTypeT3Micro, inst.InstanceType)
+		require.True(t, launchTime.Equal(*inst.LaunchTime))
+		require.Equal(t, "203.0.113.10", *inst.PublicIpAddress)
+		require.Equal(t, ec2types.InstanceStateNameRunning, inst.State.Name)
+		require.Equal(t, "sg-1", *inst.SecurityGroups[0].GroupId)
+		require.Equal(t, "web", *inst.Tags[0].Value)
+	})
+}
@@ -0,0 +1,206 @@
+package awsmocker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	readerType = reflect.TypeFor[io.Reader]()
+	timeType   = reflect.TypeFor[time.Time]()
+)
+
+// Calls fn for each member of a struct or map body. Map keys are visited in sorted order, and are
+// passed as a field that only has a Name. Unexported fields and the ResultMetadata of SDK outputs are skipped.
+func forEachMember(value reflect.Value, fn func(field reflect.StructField, value reflect.Value) error) error {
+	switch value.Kind() {
+	case reflect.Struct:
+		rType := value.Type()
+		for i := 0; i < rType.NumField(); i++ {
+			field := rType.Field(i)
+			if !field.IsExported() || field.Name == "ResultMetadata" {
+				continue
+			}
+			if err := fn(field, value.Field(i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		keys := value.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for _, key := range keys {
+			if err := fn(reflect.StructField{Name: fmt.Sprint(key.Interface())}, value.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// follows pointers and interfaces. Returns an invalid value if any are nil
+func derefValue(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// Returns true for nil members, and for zero values that are not behind a pointer.
+// SDK outputs use plain values for enums and some numbers, so those cannot be told apart from members that were not set.
+func isUnsetMember(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Pointer {
+		return value.IsNil()
+	}
+	return value.IsZero()
+}
+
+// the parts of a REST-JSON or REST-XML response, after the members have been placed
+type restParts struct {
+	headers    map[string]string
+	body       map[string]any
+	payload    []byte
+	statusCode int
+
+	hasPayload bool
+}
+
+func newRestParts() restParts {
+	return restParts{
+		headers: make(map[string]string),
+		body:    make(map[string]any),
+	}
+}
+
+func (p *restParts) setHeader(name string, value reflect.Value) {
+	if isUnsetMember(value) {
+		return
+	}
+
+	if value = derefValue(value); value.IsValid() {
+		p.headers[name] = restHeaderValue(value)
+	}
+}
+
+func (p *restParts) setPayload(value any) error {
+	if p.hasPayload {
+		return fmt.Errorf("only one member can be the payload")
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		p.payload = v
+	case string:
+		p.payload = []byte(v)
+	case io.Reader:
+		data, err := io.ReadAll(v)
+		if err != nil {
+			return err
+		}
+		p.payload = data
+	case nil:
+	default:
+		return fmt.Errorf("the payload must be a []byte, string or io.Reader, got %T", value)
+	}
+
+	p.hasPayload = true
+	return nil
+}
+
+// a payload is the entire body, so there cannot be any other body members
+func (p *restParts) checkPayload() error {
+	if !p.hasPayload {
+		return nil
+	}
+
+	var names []string
+	for name, value := range p.body {
+		if !isUnsetMember(reflect.ValueOf(value)) {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) > 0 {
+		slices.Sort(names)
+		return fmt.Errorf("members cannot be sent in the body along with a payload: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// Builds the response with the headers, status code and payload. The caller encodes the body if there is no payload.
+func (p *restParts) newResponse(statusCode int, contentType string) *httpResponse {
+	// a status code member, such as the one on lambda.InvokeOutput, is the HTTP status
+	if p.statusCode != 0 {
+		statusCode = p.statusCode
+	}
+
+	// the content type of the payload is a member of some outputs, such as GetObject
+	if ct, ok := p.headers["Content-Type"]; ok {
+		contentType = ct
+		delete(p.headers, "Content-Type")
+	}
+
+	resp := &httpResponse{
+		StatusCode:   statusCode,
+		contentType:  contentType,
+		extraHeaders: p.headers,
+	}
+
+	if p.hasPayload {
+		resp.bodyRaw = p.payload
+		if resp.contentType == "" {
+			resp.contentType = restPayloadContentType(p.payload)
+		}
+	}
+
+	return resp
+}
+
+// []byte and io.Reader values are sent as the payload, rather than base64 encoded in the body
+func isPayloadValue(value reflect.Value) bool {
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return false
+	}
+	return value.Type() == byteArrayType || value.Type().Implements(readerType)
+}
+
+func restPayloadContentType(payload []byte) string {
+	trimmed := bytes.TrimSpace(payload)
+	if bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		return ContentTypeRestJSON
+	}
+	return http.DetectContentType(payload)
+}
+
+func restHeaderValue(value reflect.Value) string {
+	value = derefValue(value)
+	if !value.IsValid() {
+		return ""
+	}
+
+	if t, ok := value.Interface().(time.Time); ok {
+		return t.UTC().Format(http.TimeFormat)
+	}
+
+	return fmt.Sprint(value.Interface())
+}
@@ -0,0 +1,46 @@
+package awsmocker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachMember(t *testing.T) {
+	collect := func(body any) []string {
+		var names []string
+		err := forEachMember(reflect.Indirect(reflect.ValueOf(body)), func(field reflect.StructField, _ reflect.Value) error {
+			names = append(names, field.Name)
+			return nil
+		})
+		require.NoError(t, err)
+		return names
+	}
+
+	require.Equal(t, []string{"a", "b", "c"}, collect(map[string]any{"c": 1, "a": 2, "b": 3}))
+	require.Equal(t, []string{"Account", "Arn", "UserId"}, collect(&sts.GetCallerIdentityOutput{}))
+	require.Empty(t, collect("not a map"))
+}
+
+func TestRestParts(t *testing.T) {
+	parts := newRestParts()
+
+	parts.setHeader("X-Set", reflect.ValueOf(aws.String("")))
+	parts.setHeader("X-Nil", reflect.ValueOf((*string)(nil)))
+	parts.setHeader("X-Zero", reflect.ValueOf(int32(0)))
+	require.Equal(t, map[string]string{"X-Set": ""}, parts.headers)
+
+	parts.body["Zero"] = int32(0)
+	parts.body["Nil"] = (*string)(nil)
+	require.NoError(t, parts.setPayload([]byte("data")))
+	require.NoError(t, parts.checkPayload())
+	require.ErrorContains(t, parts.setPayload("again"), "only one member can be the payload")
+
+	parts.body["Name"] = "thing"
+	require.EqualError(t, parts.checkPayload(), "members cannot be sent in the body along with a payload: Name")
+
+	require.ErrorContains(t, (&restParts{}).setPayload(1), "the payload must be a []byte, string or io.Reader, got int")
+}
@@ -0,0 +1,274 @@
+package awsmocker
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SDK v2 output types do not have location or json tags, so the bindings of each
+// REST-JSON service are listed here. Keys are either the member name, or the struct
+// type and member name for members that depend on the output shape
+type restJSONService struct {
+	// member names are lowerCamel on the wire, rather than the Go field name
+	lowerCamel bool
+
+	// wire names that do not follow the naming rule of the service
+	names map[string]string
+
+	// members that are sent as headers
+	headers map[string]string
+
+	// members that are the HTTP status code
+	statusCodes map[string]bool
+
+	// structure members that are the entire payload, sent as JSON
+	payloads map[string]bool
+}
+
+var restJSONServices = map[string]restJSONService{
+	"apigateway": {
+		lowerCamel: true,
+		names: map[string]string{
+			"Items":                   "item",
+			"GetUsageOutput.Items":    "values",
+			"UpdateUsageOutput.Items": "values",
+		},
+		headers: map[string]string{
+			"GetExportOutput.ContentDisposition": "Content-Disposition",
+			"GetExportOutput.ContentType":        "Content-Type",
+			"GetSdkOutput.ContentDisposition":    "Content-Disposition",
+			"GetSdkOutput.ContentType":           "Content-Type",
+		},
+	},
+	"eks": {
+		lowerCamel: true,
+	},
+	"glacier": {
+		names: map[string]string{
+			"Csv": "csv",
+		},
+		headers: map[string]string{
+			"CompleteMultipartUploadOutput.ArchiveId":      "x-amz-archive-id",
+			"CompleteMultipartUploadOutput.Checksum":       "x-amz-sha256-tree-hash",
+			"CompleteMultipartUploadOutput.Location":       "Location",
+			"CreateVaultOutput.Location":                   "Location",
+			"GetJobOutputOutput.AcceptRanges":              "Accept-Ranges",
+			"GetJobOutputOutput.ArchiveDescription":        "x-amz-archive-description",
+			"GetJobOutputOutput.Checksum":                  "x-amz-sha256-tree-hash",
+			"GetJobOutputOutput.ContentRange":              "Content-Range",
+			"GetJobOutputOutput.ContentType":               "Content-Type",
+			"InitiateJobOutput.JobId":                      "x-amz-job-id",
+			"InitiateJobOutput.JobOutputPath":              "x-amz-job-output-path",
+			"InitiateJobOutput.Location":                   "Location",
+			"InitiateMultipartUploadOutput.Location":       "Location",
+			"InitiateMultipartUploadOutput.UploadId":       "x-amz-multipart-upload-id",
+			"InitiateVaultLockOutput.LockId":               "x-amz-lock-id",
+			"PurchaseProvisionedCapacityOutput.CapacityId": "x-amz-capacity-id",
+			"UploadArchiveOutput.ArchiveId":                "x-amz-archive-id",
+			"UploadArchiveOutput.Checksum":                 "x-amz-sha256-tree-hash",
+			"UploadArchiveOutput.Location":                 "Location",
+			"UploadMultipartPartOutput.Checksum":           "x-amz-sha256-tree-hash",
+		},
+		statusCodes: map[string]bool{
+			"GetJobOutputOutput.Status": true,
+		},
+		payloads: map[string]bool{
+			"GetVaultAccessPolicyOutput.Policy":                   true,
+			"GetVaultNotificationsOutput.VaultNotificationConfig": true,
+		},
+	},
+	"lambda": {
+		headers: map[string]string{
+			"ExecutedVersion": "X-Amz-Executed-Version",
+			"FunctionError":   "X-Amz-Function-Error",
+			"LogResult":       "X-Amz-Log-Result",
+
+			"InvokeWithResponseStreamOutput.ResponseStreamContentType": "Content-Type",
+		},
+		statusCodes: map[string]bool{
+			"InvokeAsyncOutput.Status": true,
+		},
+	},
+}
+
+// the parts of a REST-JSON response, after the members have been placed
+type restJSONParts struct {
+	restParts
+	service restJSONService
+
+	// the type of the body, when it is a struct
+	parent reflect.Type
+}
+
+func (m *MockedResponse) encodeRestJSON(rr *ReceivedRequest, rBody reflect.Value) *httpResponse {
+	parts := &restJSONParts{
+		restParts: newRestParts(),
+		service:   restJSONServices[rr.Service],
+	}
+
+	var err error
+	switch {
+	case rBody.Kind() == reflect.Slice && rBody.Type() == byteArrayType:
+		err = parts.setPayload(rBody.Interface())
+	case rBody.Kind() == reflect.Map || rBody.Kind() == reflect.Struct:
+		if rBody.Kind() == reflect.Struct {
+			parts.parent = rBody.Type()
+		}
+		err = forEachMember(rBody, parts.addMember)
+	default:
+		err = fmt.Errorf("a REST-JSON body must be a map, struct or []byte, got kind=%v", rBody.Kind())
+	}
+
+	if err == nil {
+		err = parts.checkPayload()
+	}
+
+	if err != nil {
+		return generateErrorStruct(0, "BadMockBody", "Could not encode REST-JSON body: %s", err).getResponse(rr)
+	}
+
+	resp := parts.newResponse(m.StatusCode, m.ContentType)
+	if !parts.hasPayload {
+		resp.contentType = ContentTypeRestJSON
+		resp.Body = EncodeAsJson(parts.body)
+	}
+	return resp
+}
+
+func (p *restJSONParts) addMember(field reflect.StructField, value reflect.Value) error {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return nil
+	}
+	name = coalesceString(name, field.Name)
+	header := restJSONBinding(p.service.headers, p.parent, field.Name)
+
+	switch location := field.Tag.Get("location"); {
+	case location == "header":
+		p.setHeader(coalesceString(field.Tag.Get("locationName"), field.Name), value)
+	case location == "payload":
+		return p.setJSONPayload(value)
+
+	// SDK v2 outputs do not have location tags, so the members are placed using the service bindings
+	case strings.Contains(name, "-"):
+		p.setHeader(name, value)
+	case header != "":
+		p.setHeader(header, value)
+	case (name == "StatusCode" || restJSONBinding(p.service.statusCodes, p.parent, field.Name)) && derefValue(value).CanInt():
+		p.statusCode = int(derefValue(value).Int())
+	case restJSONBinding(p.service.payloads, p.parent, field.Name):
+		return p.setJSONPayload(value)
+	case isPayloadValue(value):
+		return p.setPayload(value.Interface())
+	default:
+		p.body[p.service.memberName(p.parent, field)] = p.service.toJSONValue(value)
+	}
+	return nil
+}
+
+// Looks up a member by its struct type and name, then by its name alone.
+// Maps are not a shape, so only the name is used for them
+func restJSONBinding[V any](table map[string]V, parent reflect.Type, name string) V {
+	if parent != nil {
+		if v, ok := table[parent.Name()+"."+name]; ok {
+			return v
+		}
+	}
+	return table[name]
+}
+
+// The name a member is sent as. Json tags and the fields of other structs are used as written,
+// SDK types do not have json tags so their names are derived from the service rules
+func (s restJSONService) memberName(parent reflect.Type, field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+
+	if !isSDKType(parent) {
+		return field.Name
+	}
+
+	if wire := restJSONBinding(s.names, parent, field.Name); wire != "" {
+		return wire
+	}
+
+	if s.lowerCamel {
+		return strings.ToLower(field.Name[:1]) + field.Name[1:]
+	}
+	return field.Name
+}
+
+// converts a body member to the form EncodeAsJson expects, renaming the members of SDK types
+// and sending timestamps as epoch seconds
+func (s restJSONService) toJSONValue(value reflect.Value) any {
+	value = derefValue(value)
+	if !value.IsValid() {
+		return nil
+	}
+
+	if t, ok := value.Interface().(time.Time); ok {
+		return float64(t.UnixMilli()) / 1000
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if !isSDKType(value.Type()) {
+			return value.Interface()
+		}
+
+		fallthrough
+
+	case reflect.Map:
+		var parent reflect.Type
+		if value.Kind() == reflect.Struct {
+			parent = value.Type()
+		}
+
+		result := make(map[string]any)
+		_ = forEachMember(value, func(field reflect.StructField, member reflect.Value) error {
+			if item := s.toJSONValue(member); item != nil {
+				result[s.memberName(parent, field)] = item
+			}
+			return nil
+		})
+		return result
+
+	case reflect.Slice, reflect.Array:
+		if value.Type() == byteArrayType {
+			return value.Interface()
+		}
+
+		items := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, s.toJSONValue(value.Index(i)))
+		}
+		return items
+
+	default:
+		return value.Interface()
+	}
+}
+
+// types from the service packages of the SDK
+func isSDKType(rType reflect.Type) bool {
+	return rType != nil && strings.HasPrefix(rType.PkgPath(), "github.com/aws/aws-sdk-go-v2/service/")
+}
+
+// structures bound to the payload are sent as JSON
+func (p *restJSONParts) setJSONPayload(value reflect.Value) error {
+	if isPayloadValue(value) {
+		return p.setPayload(value.Interface())
+	}
+
+	value = derefValue(value)
+	switch {
+	case !value.IsValid():
+		return p.setPayload(nil)
+	case value.Kind() == reflect.String:
+		return p.setPayload(value.String())
+	default:
+		return p.setPayload([]byte(EncodeAsJson(p.service.toJSONValue(value))))
+	}
+}
@@ -0,0 +1,189 @@
+package awsmocker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigwtypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/glacier"
+	gltypes "github.com/aws/aws-sdk-go-v2/service/glacier/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestRestJSONResponses_WithoutMiddleware(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutMiddleware(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "lambda",
+				Method:  "POST",
+				Path:    "/2015-03-31/functions/failing/invocations",
+			},
+			Response: &awsmocker.MockedResponse{
+				Encoding: awsmocker.ResponseEncodingRestJSON,
+				Body: &lambda.InvokeOutput{
+					ExecutedVersion: aws.String("$LATEST"),
+					FunctionError:   aws.String("Unhandled"),
+					LogResult:       aws.String("bG9ncw=="),
+					Payload:         []byte(`{"errorMessage":"boom"}`),
+					StatusCode:      200,
+				},
+			},
+		},
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "lambda",
+				Method:  "POST",
+				Path:    "/2015-03-31/functions/async/invocations",
+			},
+			Response: &awsmocker.MockedResponse{
+				Encoding: awsmocker.ResponseEncodingRestJSON,
+				Body:     &lambda.InvokeOutput{StatusCode: 202},
+			},
+		},
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "lambda",
+				Method:  "GET",
+				Path:    "/2015-03-31/functions/failing/configuration",
+			},
+			Response: &awsmocker.MockedResponse{
+				Encoding: awsmocker.ResponseEncodingRestJSON,
+				Body: &lambda.GetFunctionConfigurationOutput{
+					FunctionName: aws.String("failing"),
+					Runtime:      lambdatypes.RuntimeProvidedal2023,
+					Timeout:      aws.Int32(30),
+				},
+			},
+		},
+	))
+
+	client := lambda.NewFromConfig(m.Config())
+	ctx := context.TODO()
+
+	invokeResp, err := client.Invoke(ctx, &lambda.InvokeInput{FunctionName: aws.String("failing")})
+	require.NoError(t, err)
+	require.Equal(t, "$LATEST", *invokeResp.ExecutedVersion)
+	require.Equal(t, "Unhandled", *invokeResp.FunctionError)
+	require.Equal(t, "bG9ncw==", *invokeResp.LogResult)
+	require.JSONEq(t, `{"errorMessage":"boom"}`, string(invokeResp.Payload))
+	require.EqualValues(t, 200, invokeResp.StatusCode)
+
+	asyncResp, err := client.Invoke(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String("async"),
+		InvocationType: lambdatypes.InvocationTypeEvent,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 202, asyncResp.StatusCode)
+	require.Nil(t, asyncResp.FunctionError)
+
+	configResp, err := client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{FunctionName: aws.String("failing")})
+	require.NoError(t, err)
+	require.Equal(t, "failing", *configResp.FunctionName)
+	require.Equal(t, lambdatypes.RuntimeProvidedal2023, configResp.Runtime)
+	require.EqualValues(t, 30, *configResp.Timeout)
+}
+
+func TestRestJSONResponses_SDKOutputs(t *testing.T) {
+	createdAt := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutMiddleware(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{Service: "eks", Method: "GET", Path: "/clusters/testing"},
+			Response: &awsmocker.MockedResponse{
+				Encoding: awsmocker.ResponseEncodingRestJSON,
+				Body: &eks.DescribeClusterOutput{
+					Cluster: &ekstypes.Cluster{
+						Name:      aws.String("testing"),
+						Arn:       aws.String("arn:aws:eks:us-east-1:555555555555:cluster/testing"),
+						CreatedAt: &createdAt,
+						Status:    ekstypes.ClusterStatusActive,
+						Tags:      map[string]string{"Env": "test"},
+						ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+							SubnetIds: []string{"subnet-1", "subnet-2"},
+						},
+					},
+				},
+			},
+		},
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{Service: "glacier", Method: "POST", Path: "/-/vaults/archives/archives"},
+			Response: &awsmocker.MockedResponse{
+				Encoding:   awsmocker.ResponseEncodingRestJSON,
+				StatusCode: 201,
+				Body: &glacier.UploadArchiveOutput{
+					ArchiveId: aws.String("archive-1"),
+					Checksum:  aws.String("abc123"),
+					Location:  aws.String("/555555555555/vaults/archives/archives/archive-1"),
+				},
+			},
+		},
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{Service: "glacier", Method: "GET", Path: "/-/vaults/archives/access-policy"},
+			Response: &awsmocker.MockedResponse{
+				Encoding: awsmocker.ResponseEncodingRestJSON,
+				Body: &glacier.GetVaultAccessPolicyOutput{
+					Policy: &gltypes.VaultAccessPolicy{Policy: aws.String(`{"Version":"2012-10-17"}`)},
+				},
+			},
+		},
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{Service: "apigateway", Method: "GET", Path: "/restapis"},
+			Response: &awsmocker.MockedResponse{
+				Encoding: awsmocker.ResponseEncodingRestJSON,
+				Body: &apigateway.GetRestApisOutput{
+					Items: []apigwtypes.RestApi{
+						{Id: aws.String("abc123"), Name: aws.String("api"), CreatedDate: &createdAt},
+					},
+				},
+			},
+		},
+	))
+
+	ctx := context.TODO()
+
+	eksClient := eks.NewFromConfig(m.Config())
+	clusterResp, err := eksClient.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String("testing")})
+	require.NoError(t, err)
+	require.NotNil(t, clusterResp.Cluster)
+	require.Equal(t, "testing", *clusterResp.Cluster.Name)
+	require.Equal(t, "arn:aws:eks:us-east-1:555555555555:cluster/testing", *clusterResp.Cluster.Arn)
+	require.Equal(t, createdAt, clusterResp.Cluster.CreatedAt.UTC())
+	require.Equal(t, ekstypes.ClusterStatusActive, clusterResp.Cluster.Status)
+	require.Equal(t, map[string]string{"Env": "test"}, clusterResp.Cluster.Tags)
+	require.Equal(t, []string{"subnet-1", "subnet-2"}, clusterResp.Cluster.ResourcesVpcConfig.SubnetIds)
+
+	glacierClient := glacier.NewFromConfig(m.Config())
+	archiveResp, err := glacierClient.UploadArchive(ctx, &glacier.UploadArchiveInput{
+		AccountId: aws.String("-"),
+		VaultName: aws.String("archives"),
+		Checksum:  aws.String("abc123"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "archive-1", *archiveResp.ArchiveId)
+	require.Equal(t, "abc123", *archiveResp.Checksum)
+	require.Equal(t, "/555555555555/vaults/archives/archives/archive-1", *archiveResp.Location)
+
+	policyResp, err := glacierClient.GetVaultAccessPolicy(ctx, &glacier.GetVaultAccessPolicyInput{
+		AccountId: aws.String("-"),
+		VaultName: aws.String("archives"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, policyResp.Policy)
+	require.Equal(t, `{"Version":"2012-10-17"}`, *policyResp.Policy.Policy)
+
+	apigwClient := apigateway.NewFromConfig(m.Config())
+	apisResp, err := apigwClient.GetRestApis(ctx, &apigateway.GetRestApisInput{})
+	require.NoError(t, err)
+	require.Len(t, apisResp.Items, 1)
+	require.Equal(t, "abc123", *apisResp.Items[0].Id)
+	require.Equal(t, "api", *apisResp.Items[0].Name)
+	require.Equal(t, createdAt, apisResp.Items[0].CreatedDate.UTC())
+}
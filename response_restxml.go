@@ -0,0 +1,324 @@
+package awsmocker
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/clbanning/mxj"
+)
+
+const ContentTypeRestXML = "application/xml"
+
+// the XML namespace used by the responses of each REST-XML service
+var restXMLNamespaces = map[string]string{
+	"s3":         "http://s3.amazonaws.com/doc/2006-03-01/",
+	"route53":    "https://route53.amazonaws.com/doc/2013-04-01/",
+	"cloudfront": "http://cloudfront.amazonaws.com/doc/2020-05-31/",
+}
+
+// root elements that are not named after the action
+var restXMLRootTags = map[string]string{
+	"ListObjects":                     "ListBucketResult",
+	"ListObjectsV2":                   "ListBucketResult",
+	"ListObjectVersions":              "ListVersionsResult",
+	"ListBuckets":                     "ListAllMyBucketsResult",
+	"ListMultipartUploads":            "ListMultipartUploadsResult",
+	"ListParts":                       "ListPartsResult",
+	"CreateMultipartUpload":           "InitiateMultipartUploadResult",
+	"DeleteObjects":                   "DeleteResult",
+	"GetBucketTagging":                "Tagging",
+	"GetObjectTagging":                "Tagging",
+	"GetBucketLocation":               "LocationConstraint",
+	"GetBucketVersioning":             "VersioningConfiguration",
+	"GetBucketAcl":                    "AccessControlPolicy",
+	"GetObjectAcl":                    "AccessControlPolicy",
+	"GetBucketCors":                   "CORSConfiguration",
+	"GetBucketLifecycleConfiguration": "LifecycleConfiguration",
+}
+
+// members that are bound to a payload shape, which becomes the root element
+var restXMLPayloadMembers = map[string]bool{
+	"CopyObjectResult": true,
+	"CopyPartResult":   true,
+}
+
+// S3 output members that are sent as headers
+var restXMLS3Headers = map[string]string{
+	"AcceptRanges":              "Accept-Ranges",
+	"BucketKeyEnabled":          "X-Amz-Server-Side-Encryption-Bucket-Key-Enabled",
+	"CacheControl":              "Cache-Control",
+	"ChecksumCRC32":             "X-Amz-Checksum-Crc32",
+	"ChecksumCRC32C":            "X-Amz-Checksum-Crc32c",
+	"ChecksumSHA1":              "X-Amz-Checksum-Sha1",
+	"ChecksumSHA256":            "X-Amz-Checksum-Sha256",
+	"ChecksumCRC64NVME":         "X-Amz-Checksum-Crc64nvme",
+	"ChecksumType":              "X-Amz-Checksum-Type",
+	"ContentDisposition":        "Content-Disposition",
+	"ContentEncoding":           "Content-Encoding",
+	"ContentLanguage":           "Content-Language",
+	"ContentRange":              "Content-Range",
+	"ContentType":               "Content-Type",
+	"DeleteMarker":              "X-Amz-Delete-Marker",
+	"ETag":                      "ETag",
+	"Expiration":                "X-Amz-Expiration",
+	"Expires":                   "Expires",
+	"LastModified":              "Last-Modified",
+	"RequestCharged":            "X-Amz-Request-Charged",
+	"ServerSideEncryption":      "X-Amz-Server-Side-Encryption",
+	"SSEKMSKeyId":               "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id",
+	"StorageClass":              "X-Amz-Storage-Class",
+	"TagCount":                  "X-Amz-Tagging-Count",
+	"VersionId":                 "X-Amz-Version-Id",
+	"WebsiteRedirectLocation":   "X-Amz-Website-Redirect-Location",
+	"CopySourceVersionId":       "X-Amz-Copy-Source-Version-Id",
+	"PartsCount":                "X-Amz-Mp-Parts-Count",
+	"ReplicationStatus":         "X-Amz-Replication-Status",
+	"ObjectLockMode":            "X-Amz-Object-Lock-Mode",
+	"ObjectLockLegalHoldStatus": "X-Amz-Object-Lock-Legal-Hold",
+}
+
+// actions where some of the S3 header members are in the body instead
+var restXMLS3BodyMembers = map[string][]string{
+	"CompleteMultipartUpload": {"ETag", "ChecksumCRC32", "ChecksumCRC32C", "ChecksumSHA1", "ChecksumSHA256"},
+}
+
+// how list members are written. Lists that are not listed here are flattened, using the member name for each item
+type restXMLList struct {
+	// the name of each item
+	item string
+
+	// if true, the items are wrapped in an element named after the member
+	wrapped bool
+}
+
+var restXMLLists = map[string]restXMLList{
+	// s3
+	"Buckets":       {item: "Bucket", wrapped: true},
+	"TagSet":        {item: "Tag", wrapped: true},
+	"Grants":        {item: "Grant", wrapped: false},
+	"Parts":         {item: "Part"},
+	"Uploads":       {item: "Upload"},
+	"Versions":      {item: "Version"},
+	"DeleteMarkers": {item: "DeleteMarker"},
+	"Errors":        {item: "Error"},
+	"CORSRules":     {item: "CORSRule"},
+	"Rules":         {item: "Rule"},
+
+	// route53
+	"HostedZones":        {item: "HostedZone", wrapped: true},
+	"ResourceRecordSets": {item: "ResourceRecordSet", wrapped: true},
+	"ResourceRecords":    {item: "ResourceRecord", wrapped: true},
+	"HealthChecks":       {item: "HealthCheck", wrapped: true},
+	"VPCs":               {item: "VPC", wrapped: true},
+	"NameServers":        {item: "NameServer", wrapped: true},
+}
+
+// the parts of a REST-XML response, after the members have been placed
+type restXMLParts struct {
+	restParts
+	service string
+	action  string
+}
+
+func (m *MockedResponse) encodeRestXML(rr *ReceivedRequest, rBody reflect.Value, actionName string) *httpResponse {
+	parts := &restXMLParts{
+		restParts: newRestParts(),
+		service:   rr.Service,
+		action:    actionName,
+	}
+
+	var err error
+	switch {
+	case rBody.Kind() == reflect.Slice && rBody.Type() == byteArrayType:
+		err = parts.setPayload(rBody.Interface())
+	case rBody.Kind() == reflect.Map || rBody.Kind() == reflect.Struct:
+		err = forEachMember(rBody, parts.addMember)
+	default:
+		err = fmt.Errorf("a REST-XML body must be a map, struct or []byte, got kind=%v", rBody.Kind())
+	}
+
+	if err == nil {
+		err = parts.checkPayload()
+	}
+
+	if err != nil {
+		return generateErrorStruct(0, "BadMockBody", "Could not encode REST-XML body: %s", err).getResponse(rr)
+	}
+
+	resp := parts.newResponse(m.StatusCode, m.ContentType)
+	if parts.hasPayload {
+		return resp
+	}
+
+	resp.contentType = coalesceString(m.ContentType, ContentTypeRestXML)
+	if len(parts.body) == 0 {
+		return resp
+	}
+
+	rootTag, body := parts.root(m.RootTag)
+
+	if ns, ok := restXMLNamespaces[parts.service]; ok {
+		body["-xmlns"] = ns
+	}
+
+	xmlout, xerr := mxj.AnyXmlIndent(body, "", "  ", rootTag)
+	if xerr != nil {
+		return generateErrorStruct(0, "BadMockBody", "Could not serialize body to XML: %s", xerr).getResponse(rr)
+	}
+
+	resp.bodyRaw = append([]byte(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"), xmlout...)
+	return resp
+}
+
+// the name of the root element, and the body to put inside of it
+func (p *restXMLParts) root(rootTag string) (string, map[string]any) {
+	if rootTag != "" {
+		return rootTag, p.body
+	}
+
+	// outputs with a payload shape use it as the root
+	if len(p.body) == 1 {
+		for k, v := range p.body {
+			if inner, ok := v.(map[string]any); ok && (restXMLPayloadMembers[k] || p.service == "cloudfront") {
+				return k, inner
+			}
+		}
+	}
+
+	if tag, ok := restXMLRootTags[p.action]; ok {
+		return tag, p.body
+	}
+
+	if p.service == "route53" {
+		return p.action + "Response", p.body
+	}
+
+	return p.action + "Result", p.body
+}
+
+func (p *restXMLParts) addMember(field reflect.StructField, value reflect.Value) error {
+	if !derefValue(value).IsValid() {
+		return nil
+	}
+
+	// readers are checked before dereferencing, as most have pointer receivers
+	if isPayloadValue(value) {
+		return p.setPayload(value.Interface())
+	}
+
+	name := field.Name
+	location := field.Tag.Get("location")
+	value = derefValue(value)
+
+	switch {
+	case location == "payload":
+		return p.setPayload(value.Interface())
+
+	case location == "header":
+		p.setHeader(coalesceString(field.Tag.Get("locationName"), name), value)
+
+	case strings.Contains(name, "-"):
+		p.setHeader(name, value)
+
+	case name == "Metadata" && value.Kind() == reflect.Map:
+		for _, key := range value.MapKeys() {
+			p.setHeader("X-Amz-Meta-"+fmt.Sprint(key.Interface()), value.MapIndex(key))
+		}
+
+	case p.isS3Header(name) && (value.Kind() != reflect.Map && value.Kind() != reflect.Struct || value.Type() == timeType):
+		p.setHeader(restXMLS3Headers[name], value)
+
+	default:
+		addRestXMLValue(p.body, name, value)
+	}
+
+	return nil
+}
+
+func (p *restXMLParts) isS3Header(name string) bool {
+	if p.service != "s3" {
+		return false
+	}
+
+	if _, ok := restXMLS3Headers[name]; !ok {
+		return false
+	}
+
+	for _, bodyMember := range restXMLS3BodyMembers[p.action] {
+		if bodyMember == name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// adds a member to an XML element, converting lists and nested structs to what mxj expects
+func addRestXMLValue(dst map[string]any, name string, value reflect.Value) {
+	value = derefValue(value)
+	if !value.IsValid() {
+		return
+	}
+
+	if value.Kind() != reflect.Slice || value.Type() == byteArrayType {
+		dst[name] = toRestXMLValue(value)
+		return
+	}
+
+	items := make([]any, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		if item := derefValue(value.Index(i)); item.IsValid() {
+			items = append(items, toRestXMLValue(item))
+		}
+	}
+
+	list, ok := restXMLLists[name]
+	switch {
+	case !ok:
+		dst[name] = items
+	case list.wrapped:
+		dst[name] = map[string]any{list.item: items}
+	default:
+		dst[list.item] = items
+	}
+}
+
+func toRestXMLValue(value reflect.Value) any {
+	value = derefValue(value)
+	if !value.IsValid() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if t, ok := value.Interface().(time.Time); ok {
+			return t.UTC().Format("2006-01-02T15:04:05.000Z")
+		}
+
+		fallthrough
+
+	case reflect.Map:
+		result := make(map[string]any)
+		_ = forEachMember(value, func(field reflect.StructField, member reflect.Value) error {
+			addRestXMLValue(result, field.Name, member)
+			return nil
+		})
+		return result
+
+	case reflect.Slice:
+		if value.Type() == byteArrayType {
+			return base64.StdEncoding.EncodeToString(value.Bytes())
+		}
+		items := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, toRestXMLValue(value.Index(i)))
+		}
+		return items
+
+	default:
+		return value.Interface()
+	}
+}
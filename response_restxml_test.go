@@ -0,0 +1,187 @@
+package awsmocker_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestRestXMLResponses(t *testing.T) {
+	lastModified := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	restXML := func(action string, body any) *awsmocker.MockedEndpoint {
+		return &awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "s3",
+				Action:  action,
+			},
+			Response: &awsmocker.MockedResponse{
+				Body:     body,
+				Encoding: awsmocker.ResponseEncodingRestXML,
+			},
+		}
+	}
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		restXML("ListObjectsV2", map[string]any{
+			"Name":        "mybucket",
+			"KeyCount":    2,
+			"IsTruncated": false,
+			"Contents": []map[string]any{
+				{"Key": "a.txt", "Size": 10, "ETag": `"abc"`},
+				{"Key": "b.txt", "Size": 20, "LastModified": lastModified},
+			},
+			"CommonPrefixes": []map[string]any{
+				{"Prefix": "logs/"},
+			},
+		}),
+		restXML("GetObject", &s3.GetObjectOutput{
+			Body:          io.NopCloser(strings.NewReader("hello world")),
+			ETag:          aws.String(`"etag-1"`),
+			VersionId:     aws.String("v1"),
+			ContentRange:  aws.String("bytes 0-10/11"),
+			ContentType:   aws.String("text/plain"),
+			ContentLength: aws.Int64(11),
+			LastModified:  aws.Time(lastModified),
+			Metadata:      map[string]string{"owner": "bob"},
+		}),
+		restXML("GetBucketTagging", &s3.GetBucketTaggingOutput{
+			TagSet: []s3types.Tag{
+				{Key: aws.String("env"), Value: aws.String("test")},
+				{Key: aws.String("team"), Value: aws.String("infra")},
+			},
+		}),
+		restXML("CopyObject", &s3.CopyObjectOutput{
+			CopyObjectResult: &s3types.CopyObjectResult{
+				ETag:         aws.String(`"copied"`),
+				LastModified: aws.Time(lastModified),
+			},
+			VersionId: aws.String("v2"),
+		}),
+		restXML("CompleteMultipartUpload", map[string]any{
+			"Bucket":    "mybucket",
+			"Key":       "big.bin",
+			"ETag":      `"multi-3"`,
+			"VersionId": "v3",
+		}),
+	))
+
+	client := s3.NewFromConfig(m.Config(), func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+	ctx := context.TODO()
+
+	listResp, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{Bucket: aws.String("mybucket")})
+	require.NoError(t, err)
+	require.Equal(t, "mybucket", *listResp.Name)
+	require.Len(t, listResp.Contents, 2)
+	require.Equal(t, "a.txt", *listResp.Contents[0].Key)
+	require.Equal(t, `"abc"`, *listResp.Contents[0].ETag)
+	require.EqualValues(t, 20, *listResp.Contents[1].Size)
+	require.True(t, lastModified.Equal(*listResp.Contents[1].LastModified))
+	require.Len(t, listResp.CommonPrefixes, 1)
+	require.Equal(t, "logs/", *listResp.CommonPrefixes[0].Prefix)
+
+	getResp, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("mybucket"), Key: aws.String("a.txt")})
+	require.NoError(t, err)
+	data, err := io.ReadAll(getResp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(data))
+	require.Equal(t, `"etag-1"`, *getResp.ETag)
+	require.Equal(t, "v1", *getResp.VersionId)
+	require.Equal(t, "bytes 0-10/11", *getResp.ContentRange)
+	require.Equal(t, "text/plain", *getResp.ContentType)
+	require.True(t, lastModified.Equal(*getResp.LastModified))
+	require.Equal(t, map[string]string{"owner": "bob"}, getResp.Metadata)
+
+	tagResp, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String("mybucket")})
+	require.NoError(t, err)
+	require.Len(t, tagResp.TagSet, 2)
+	require.Equal(t, "team", *tagResp.TagSet[1].Key)
+	require.Equal(t, "infra", *tagResp.TagSet[1].Value)
+
+	copyResp, err := client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String("mybucket"),
+		Key:        aws.String("copy.txt"),
+		CopySource: aws.String("mybucket/a.txt"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "v2", *copyResp.VersionId)
+	require.Equal(t, `"copied"`, *copyResp.CopyObjectResult.ETag)
+
+	completeResp, err := client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String("mybucket"),
+		Key:      aws.String("big.bin"),
+		UploadId: aws.String("upload-1"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, `"multi-3"`, *completeResp.ETag)
+	require.Equal(t, "big.bin", *completeResp.Key)
+	require.Equal(t, "v3", *completeResp.VersionId)
+}
+
+func TestRestXMLResponses_WithoutMiddleware(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutMiddleware(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "s3",
+				Method:  "GET",
+				Path:    "/mybucket/a.txt",
+			},
+			Response: &awsmocker.MockedResponse{
+				Encoding: awsmocker.ResponseEncodingRestXML,
+				Body: &s3.GetObjectOutput{
+					Body:         io.NopCloser(strings.NewReader("hello world")),
+					ETag:         aws.String(`"etag-1"`),
+					VersionId:    aws.String("v1"),
+					ContentRange: aws.String("bytes 0-10/11"),
+					Metadata:     map[string]string{"owner": "bob"},
+				},
+			},
+		},
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "s3",
+				Method:  "GET",
+				Path:    "/mybucket",
+			},
+			Response: &awsmocker.MockedResponse{
+				Encoding: awsmocker.ResponseEncodingRestXML,
+				Body: &s3.GetBucketTaggingOutput{
+					TagSet: []s3types.Tag{
+						{Key: aws.String("env"), Value: aws.String("test")},
+					},
+				},
+			},
+		},
+	))
+
+	client := s3.NewFromConfig(m.Config(), func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+	ctx := context.TODO()
+
+	getResp, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("mybucket"), Key: aws.String("a.txt")})
+	require.NoError(t, err)
+	data, err := io.ReadAll(getResp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(data))
+	require.Equal(t, `"etag-1"`, *getResp.ETag)
+	require.Equal(t, "v1", *getResp.VersionId)
+	require.Equal(t, "bytes 0-10/11", *getResp.ContentRange)
+	require.Equal(t, map[string]string{"owner": "bob"}, getResp.Metadata)
+
+	tagResp, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String("mybucket")})
+	require.NoError(t, err)
+	require.Len(t, tagResp.TagSet, 1)
+	require.Equal(t, "env", *tagResp.TagSet[0].Key)
+	require.Equal(t, "test", *tagResp.TagSet[0].Value)
+}
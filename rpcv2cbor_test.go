@@ -0,0 +1,79 @@
+package awsmocker_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go/encoding/cbor"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestRpcV2Cbor(t *testing.T) {
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Service: "monitoring",
+				Action:  "GetMetricData",
+				JMESPathMatches: map[string]any{
+					"MetricDataQueries[0].Id": "q1",
+					"MaxDatapoints":           100,
+				},
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"MetricDataResults": []map[string]any{
+						{"Id": "q1", "Values": []float64{1.5, 2}},
+					},
+				},
+			},
+		},
+		awsmocker.Mock_Failure_WithCode(404, "monitoring", "GetDashboard", "ResourceNotFound", "Dashboard does not exist"),
+	))
+
+	client := &http.Client{
+		Transport: &http.Transport{Proxy: m.Proxy()},
+	}
+
+	send := func(operation string, body cbor.Map) (*http.Response, cbor.Map) {
+		req, err := http.NewRequest(http.MethodPost, "http://monitoring.us-east-1.amazonaws.com/service/GraniteServiceVersion20100801/operation/"+operation, bytes.NewReader(cbor.Encode(body)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/cbor")
+		req.Header.Set("Smithy-Protocol", "rpc-v2-cbor")
+		req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=XXfakekey/20240101/us-east-1/monitoring/aws4_request")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		decoded, err := cbor.Decode(data)
+		require.NoError(t, err)
+		return resp, decoded.(cbor.Map)
+	}
+
+	resp, body := send("GetMetricData", cbor.Map{
+		"MetricDataQueries": cbor.List{cbor.Map{"Id": cbor.String("q1")}},
+		"MaxDatapoints":     cbor.Uint(100),
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, awsmocker.ContentTypeCBOR, resp.Header.Get("Content-Type"))
+	require.Equal(t, "rpc-v2-cbor", resp.Header.Get("Smithy-Protocol"))
+
+	result := body["MetricDataResults"].(cbor.List)[0].(cbor.Map)
+	require.Equal(t, cbor.String("q1"), result["Id"])
+	require.Equal(t, cbor.List{cbor.Float64(1.5), cbor.Float64(2)}, result["Values"])
+
+	resp, body = send("GetDashboard", cbor.Map{"DashboardName": cbor.String("nope")})
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, "rpc-v2-cbor", resp.Header.Get("Smithy-Protocol"))
+	require.Equal(t, cbor.String("ResourceNotFound"), body["__type"])
+	require.Equal(t, cbor.String("Dashboard does not exist"), body["message"])
+
+	requests := m.RequestsFor("monitoring", "GetMetricData")
+	require.Len(t, requests, 1)
+	require.Equal(t, float64(100), requests[0].CborPayload.(map[string]any)["MaxDatapoints"])
+}
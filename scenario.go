@@ -0,0 +1,44 @@
+package awsmocker
+
+import "sync"
+
+// The state every scenario is in until a mock moves it somewhere else
+const ScenarioStateStarted = "Started"
+
+// holds the current state of each named scenario
+type scenarioStore struct {
+	mu     sync.Mutex
+	states map[string]string
+}
+
+func newScenarioStore() *scenarioStore {
+	return &scenarioStore{
+		states: make(map[string]string),
+	}
+}
+
+func (s *scenarioStore) get(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state, ok := s.states[name]; ok {
+		return state
+	}
+	return ScenarioStateStarted
+}
+
+func (s *scenarioStore) set(name, state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[name] = state
+}
+
+// Returns the current state of the named scenario
+func (m *mocker) ScenarioState(name string) string {
+	return m.scenarios.get(name)
+}
+
+// Forces the named scenario into the given state
+func (m *mocker) SetScenarioState(name, state string) {
+	m.scenarios.set(name, state)
+}
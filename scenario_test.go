@@ -0,0 +1,89 @@
+package awsmocker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestScenarios(t *testing.T) {
+	describeServices := func(requiredState, newState, status string) *awsmocker.MockedEndpoint {
+		return &awsmocker.MockedEndpoint{
+			Scenario:      "service",
+			RequiredState: requiredState,
+			NewState:      newState,
+			Request: &awsmocker.MockedRequest{
+				Service: "ecs",
+				Action:  "DescribeServices",
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"services": []map[string]any{
+						{
+							"serviceName": "someservice",
+							"status":      status,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Scenario: "service",
+			NewState: "creating",
+			Request: &awsmocker.MockedRequest{
+				Service: "ecs",
+				Action:  "CreateService",
+			},
+			Response: &awsmocker.MockedResponse{
+				Body: map[string]any{
+					"service": map[string]any{
+						"serviceName": "someservice",
+					},
+				},
+			},
+		},
+		describeServices("", "", "MISSING"),
+		describeServices("creating", "complete", "PENDING"),
+		describeServices("complete", "", "ACTIVE"),
+	))
+
+	client := ecs.NewFromConfig(m.Config())
+
+	describe := func() string {
+		resp, err := client.DescribeServices(context.TODO(), &ecs.DescribeServicesInput{
+			Services: []string{"someservice"},
+			Cluster:  aws.String("testcluster"),
+		})
+		require.NoError(t, err)
+		return *resp.Services[0].Status
+	}
+
+	require.Equal(t, awsmocker.ScenarioStateStarted, m.ScenarioState("service"))
+	require.Equal(t, "MISSING", describe())
+
+	_, err := client.CreateService(context.TODO(), &ecs.CreateServiceInput{
+		ServiceName: aws.String("someservice"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "creating", m.ScenarioState("service"))
+
+	require.Equal(t, "PENDING", describe())
+	require.Equal(t, "complete", m.ScenarioState("service"))
+	require.Equal(t, "ACTIVE", describe())
+	require.Equal(t, "ACTIVE", describe())
+
+	m.SetScenarioState("service", "creating")
+	require.Equal(t, "PENDING", describe())
+
+	m.SetScenarioState("service", awsmocker.ScenarioStateStarted)
+	require.Equal(t, "MISSING", describe())
+
+	require.Equal(t, awsmocker.ScenarioStateStarted, m.ScenarioState("some-other-scenario"))
+}
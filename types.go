@@ -53,6 +53,43 @@ const (
 	ResponseEncodingJSON
 	ResponseEncodingXML
 	ResponseEncodingText
+
+	// REST-JSON services (Lambda, API Gateway, EKS, Glacier, etc). Members can be bound to
+	// headers and the payload, as well as the JSON body. See [MockedResponse.Body]
+	ResponseEncodingRestJSON
+
+	// REST-XML services (S3, Route53, CloudFront). The body is written under a bare root element with
+	// the service namespace, and members bound to headers or the payload are placed there. See [MockedResponse.Body]
+	ResponseEncodingRestXML
+
+	// Smithy RPCv2 CBOR. This is used by default when the request used that protocol
+	ResponseEncodingCBOR
+)
+
+// What a [MockedEndpoint] does once it has returned every response in its Responses sequence
+type ResponseSequenceMode int
+
+const (
+	// Default will keep returning the last response in the sequence
+	ResponseSequenceRepeatLast ResponseSequenceMode = iota
+
+	// Start over from the first response in the sequence
+	ResponseSequenceCycle
+
+	// Return an error response and fail the test
+	ResponseSequenceFail
+)
+
+// How arrays are compared by [MockedRequest.JSONContains]
+type ArrayMatchMode int
+
+const (
+	// Default requires arrays to be the same length, with each element matching the one at the same index
+	ArrayMatchInOrder ArrayMatchMode = iota
+
+	// Each expected element must match a different element of the actual array, in any order.
+	// The actual array can have extra elements.
+	ArrayMatchAsSet
 )
 
 type MockedRequestHandler = func(*ReceivedRequest) *http.Response
@@ -0,0 +1,58 @@
+package awsmocker
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type unusedMocksMode int
+
+const (
+	unusedMocksIgnore unusedMocksMode = iota
+	unusedMocksWarn
+	unusedMocksFail
+)
+
+// returns the mocks that never matched a request and should be reported
+func (m *mocker) unusedMockList() []*MockedEndpoint {
+	unused := make([]*MockedEndpoint, 0)
+	for _, mock := range m.activeMocks() {
+		if mock.Optional || isDefaultMock(mock) {
+			continue
+		}
+
+		// mocks that expect to never be called are handled by the call expectations
+		if mock.expectedCalls != nil && mock.expectedCalls.max == 0 {
+			continue
+		}
+
+		if mock.Request.MatchCount() == 0 {
+			unused = append(unused, mock)
+		}
+	}
+	return unused
+}
+
+func (m *mocker) reportUnusedMocks() {
+	if m.unusedMocks == unusedMocksIgnore {
+		return
+	}
+
+	unused := m.unusedMockList()
+	if len(unused) == 0 {
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "%d mocks were never matched by a request:", len(unused))
+	for _, mock := range unused {
+		fmt.Fprintf(buf, "\n  - %s", mock.Request.Inspect())
+	}
+
+	if m.unusedMocks == unusedMocksFail {
+		m.t.Errorf("%s", buf.String())
+		return
+	}
+
+	m.Warnf("%s", buf.String())
+}
@@ -0,0 +1,81 @@
+package awsmocker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestUnusedMocks(t *testing.T) {
+	tables := []struct {
+		name   string
+		opt    awsmocker.MockerOptionFunc
+		mocks  func() []*awsmocker.MockedEndpoint
+		errors []string
+		logs   []string
+	}{
+		{
+			name: "Disabled",
+			mocks: func() []*awsmocker.MockedEndpoint {
+				return []*awsmocker.MockedEndpoint{awsmocker.NewSimpleMockedEndpoint("ecs", "ListClustrs", map[string]any{})}
+			},
+		},
+		{
+			name: "Fail",
+			opt:  awsmocker.WithFailOnUnusedMocks(),
+			mocks: func() []*awsmocker.MockedEndpoint {
+				return []*awsmocker.MockedEndpoint{
+					awsmocker.NewSimpleMockedEndpoint("ecs", "ListClustrs", map[string]any{}),
+					awsmocker.NewSimpleMockedEndpoint("ecs", "DescribeClusters", map[string]any{}),
+				}
+			},
+			errors: []string{"2 mocks were never matched by a request:\n  - MReq<Service=ecs Action=ListClustrs>\n  - MReq<Service=ecs Action=DescribeClusters>"},
+		},
+		{
+			name: "Warn",
+			opt:  awsmocker.WithWarnOnUnusedMocks(),
+			mocks: func() []*awsmocker.MockedEndpoint {
+				return []*awsmocker.MockedEndpoint{awsmocker.NewSimpleMockedEndpoint("ecs", "ListClustrs", map[string]any{})}
+			},
+			logs: []string{"[AWSMOCKER] WARN: 1 mocks were never matched by a request:\n  - MReq<Service=ecs Action=ListClustrs>"},
+		},
+		{
+			name: "Optional",
+			opt:  awsmocker.WithFailOnUnusedMocks(),
+			mocks: func() []*awsmocker.MockedEndpoint {
+				mock := awsmocker.NewSimpleMockedEndpoint("ecs", "DescribeClusters", map[string]any{})
+				mock.Optional = true
+				return append(awsmocker.Mock_IMDS_Common(), mock, awsmocker.NewSimpleMockedEndpoint("ecs", "ListServices", map[string]any{}).Never())
+			},
+		},
+	}
+
+	for _, table := range tables {
+		var tm *TestingMock
+
+		t.Run(table.name, func(t *testing.T) {
+			tm = NewTestingMock(t)
+
+			m := awsmocker.Start(tm, table.opt, awsmocker.WithMocks(table.mocks()...), awsmocker.WithMocks(
+				awsmocker.NewSimpleMockedEndpoint("ecs", "ListClusters", map[string]any{}),
+			))
+
+			_, err := ecs.NewFromConfig(m.Config()).ListClusters(context.TODO(), &ecs.ListClustersInput{})
+			require.NoError(t, err)
+		})
+
+		// unused mocks are reported during cleanup, which has run by now
+		require.Equal(t, table.errors, nilIfEmpty(tm.errorMessages), table.name)
+		require.Equal(t, table.logs, nilIfEmpty(tm.logMessages), table.name)
+	}
+}
+
+func nilIfEmpty(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
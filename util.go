@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 )
@@ -30,6 +31,30 @@ func EncodeAsJson(obj any) string {
 	return string(out)
 }
 
+// Converts a value into the same form it would have if it had been decoded from JSON.
+// strings and byte slices are treated as JSON documents, everything else is encoded first.
+func normalizeJSON(value any) (any, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("value cannot be encoded as JSON: %w", err)
+		}
+		raw = encoded
+	}
+
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("value is not valid JSON: %w", err)
+	}
+	return out, nil
+}
+
 func inferContentType(value string) string {
 	switch {
 	case strings.HasPrefix(value, "<"):
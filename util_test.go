@@ -22,3 +22,26 @@ func TestInferContentType(t *testing.T) {
 		require.Equal(t, table.exp, inferContentType(table.str))
 	}
 }
+
+func TestMustNormalizeJSON(t *testing.T) {
+	expected := map[string]any{"a": "x", "b": []any{float64(1), float64(2)}}
+
+	require.Equal(t, expected, mustNormalizeJSON(`{"b":[1,2],"a":"x"}`))
+	require.Equal(t, expected, mustNormalizeJSON([]byte(`{"a":"x","b":[1,2]}`)))
+	require.Equal(t, expected, mustNormalizeJSON(map[string]any{"a": "x", "b": []int{1, 2}}))
+	require.Equal(t, expected, mustNormalizeJSON(struct {
+		A string `json:"a"`
+		B []int  `json:"b"`
+	}{"x", []int{1, 2}}))
+
+	require.Panics(t, func() { mustNormalizeJSON("not json") })
+}
+
+// normalizeJSON for values that are known to be valid
+func mustNormalizeJSON(value any) any {
+	out, err := normalizeJSON(value)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package awsmocker
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Matches a single string value of a request, such as the action or hostname.
+//
+// A [*regexp.Regexp] can be used directly as a ValueMatcher.
+type ValueMatcher interface {
+	MatchString(string) bool
+
+	// Describes the matcher. This is used by Inspect and in reports of unmatched requests
+	String() string
+}
+
+var _ ValueMatcher = (*regexp.Regexp)(nil)
+
+type globMatcher struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func (g globMatcher) MatchString(value string) bool {
+	return g.re.MatchString(value)
+}
+
+func (g globMatcher) String() string {
+	return "glob(" + g.pattern + ")"
+}
+
+// Matches values using a shell style pattern. A '*' matches any sequence of characters,
+// and a '?' matches a single character. Everything else must match exactly.
+//
+//	Glob("Describe*")
+//	Glob("*.us-west-2.amazonaws.com")
+func Glob(pattern string) ValueMatcher {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	return globMatcher{pattern: pattern, re: regexp.MustCompile(expr.String())}
+}
+
+type oneOfMatcher []string
+
+func (o oneOfMatcher) MatchString(value string) bool {
+	return slices.Contains(o, value)
+}
+
+func (o oneOfMatcher) String() string {
+	return "oneOf(" + strings.Join(o, ",") + ")"
+}
+
+// Matches values that are exactly equal to one of the options
+func OneOf(options ...string) ValueMatcher {
+	return oneOfMatcher(options)
+}
+
+type prefixMatcher string
+
+func (p prefixMatcher) MatchString(value string) bool {
+	return strings.HasPrefix(value, string(p))
+}
+
+func (p prefixMatcher) String() string {
+	return "prefix(" + string(p) + ")"
+}
+
+// Matches values that start with the prefix
+func Prefix(prefix string) ValueMatcher {
+	return prefixMatcher(prefix)
+}
+
+// Matches values using a regular expression. This will panic if the expression is invalid.
+func Regex(expr string) ValueMatcher {
+	return regexp.MustCompile(expr)
+}
+
+type funcMatcher func(string) bool
+
+func (f funcMatcher) MatchString(value string) bool {
+	return f(value)
+}
+
+func (f funcMatcher) String() string {
+	return "<func>"
+}
+
+// Matches values for which the function returns true
+func Func(fn func(string) bool) ValueMatcher {
+	return funcMatcher(fn)
+}
+
+// renders a matcher for Inspect. Regexes are shown the same way as PathRegex
+func inspectValueMatcher(vm ValueMatcher) string {
+	if re, ok := vm.(*regexp.Regexp); ok {
+		return "~" + re.String()
+	}
+	return vm.String()
+}
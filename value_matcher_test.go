@@ -0,0 +1,105 @@
+package awsmocker
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueMatchers(t *testing.T) {
+	tables := []struct {
+		matcher ValueMatcher
+		str     string
+		matches []string
+		misses  []string
+	}{
+		{Glob("Describe*"), "glob(Describe*)", []string{"Describe", "DescribeClusters"}, []string{"ListClusters", "describeClusters"}},
+		{Glob("ec2.*.amazonaws.com"), "glob(ec2.*.amazonaws.com)", []string{"ec2.us-west-2.amazonaws.com"}, []string{"ec2XusXamazonaws.com", "sts.us-west-2.amazonaws.com"}},
+		{Glob("Get?tem"), "glob(Get?tem)", []string{"GetItem"}, []string{"Getitem2", "Gettem"}},
+		{OneOf("PutItem", "UpdateItem"), "oneOf(PutItem,UpdateItem)", []string{"PutItem", "UpdateItem"}, []string{"GetItem", "PutItems"}},
+		{Prefix("/v1/"), "prefix(/v1/)", []string{"/v1/", "/v1/things"}, []string{"/v2/things", "v1/"}},
+		{Regex(`^List(Clusters|Services)$`), "^List(Clusters|Services)$", []string{"ListClusters", "ListServices"}, []string{"ListTasks"}},
+		{Func(func(s string) bool { return len(s) == 3 }), "<func>", []string{"GET", "PUT"}, []string{"POST"}},
+	}
+
+	for _, table := range tables {
+		require.Equal(t, table.str, table.matcher.String())
+		for _, v := range table.matches {
+			require.Truef(t, table.matcher.MatchString(v), "%s should match %q", table.str, v)
+		}
+		for _, v := range table.misses {
+			require.Falsef(t, table.matcher.MatchString(v), "%s should not match %q", table.str, v)
+		}
+	}
+
+	require.Panics(t, func() { Regex("(") })
+}
+
+func TestMockedRequest_ValueMatchers(t *testing.T) {
+	mr := &MockedRequest{
+		HostnameMatch: Glob("*.us-east-1.amazonaws.com"),
+		ServiceMatch:  OneOf("ec2", "ecs"),
+		ActionMatch:   Glob("Describe*"),
+		PathMatch:     Prefix("/"),
+		MethodMatch:   regexp.MustCompile(`^(GET|POST)$`),
+		ParamsMatch: map[string]ValueMatcher{
+			"InstanceId.1": Prefix("i-"),
+		},
+		Strict: true,
+		Params: url.Values{"Version": []string{"2016-11-15"}},
+	}
+
+	newRR := func(action string, form url.Values) *ReceivedRequest {
+		return &ReceivedRequest{
+			Hostname:    "ec2.us-east-1.amazonaws.com",
+			Service:     "ec2",
+			Action:      action,
+			Path:        "/",
+			HttpRequest: &http.Request{Method: http.MethodPost, Form: form},
+		}
+	}
+
+	require.True(t, mr.matchRequest(newRR("DescribeInstances", url.Values{
+		"Version":      []string{"2016-11-15"},
+		"InstanceId.1": []string{"i-12345"},
+	})))
+
+	require.False(t, mr.matchRequest(newRR("RunInstances", url.Values{
+		"Version":      []string{"2016-11-15"},
+		"InstanceId.1": []string{"i-12345"},
+	})))
+
+	require.False(t, mr.matchRequest(newRR("DescribeInstances", url.Values{
+		"Version":      []string{"2016-11-15"},
+		"InstanceId.1": []string{"vol-12345"},
+	})))
+
+	// strict mode still rejects params that are not listed
+	require.False(t, mr.matchRequest(newRR("DescribeInstances", url.Values{
+		"Version":      []string{"2016-11-15"},
+		"InstanceId.1": []string{"i-12345"},
+		"DryRun":       []string{"true"},
+	})))
+
+	require.Equal(t, 8, mr.specificity())
+
+	result := mr.Inspect()
+	for _, part := range []string{
+		"HostnameMatch=glob(*.us-east-1.amazonaws.com)",
+		"ServiceMatch=oneOf(ec2,ecs)",
+		"ActionMatch=glob(Describe*)",
+		"PathMatch=prefix(/)",
+		"MethodMatch=~^(GET|POST)$",
+		"ParamsMatch=[InstanceId.1=prefix(i-)]",
+	} {
+		require.True(t, strings.Contains(result, part), "missing %s in %s", part, result)
+	}
+
+	checks := mr.checkRequest(newRR("RunInstances", url.Values{}), false)
+	require.Contains(t, checks.checks, matchCheck{field: "ActionMatch", expected: "glob(Describe*)", actual: `"RunInstances"`})
+	require.Contains(t, checks.checks, matchCheck{field: "ParamsMatch[InstanceId.1]", expected: "prefix(i-)", actual: "<absent>"})
+}
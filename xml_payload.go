@@ -0,0 +1,39 @@
+package awsmocker
+
+// XML elements whose children are list items. mxj only creates a list when an element repeats,
+// so these are always turned into lists, and a single item can still be matched with [0]
+var xmlListElements = map[string]string{
+	// route53
+	"Changes":         "Change",
+	"ResourceRecords": "ResourceRecord",
+
+	// s3
+	"CompleteMultipartUpload": "Part",
+	"CORSConfiguration":       "CORSRule",
+	"Delete":                  "Object",
+	"LifecycleConfiguration":  "Rule",
+	"TagSet":                  "Tag",
+}
+
+// walks a parsed XML document and turns the children of known list elements into lists
+func normalizeXmlLists(node any) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if item, ok := xmlListElements[key]; ok {
+				if wrapper, ok := child.(map[string]any); ok {
+					if single, ok := wrapper[item]; ok {
+						if _, isList := single.([]any); !isList {
+							wrapper[item] = []any{single}
+						}
+					}
+				}
+			}
+			normalizeXmlLists(child)
+		}
+	case []any:
+		for _, child := range v {
+			normalizeXmlLists(child)
+		}
+	}
+}
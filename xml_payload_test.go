@@ -0,0 +1,104 @@
+package awsmocker_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/webdestroya/awsmocker"
+)
+
+func TestXmlPayloadMatching(t *testing.T) {
+	const changeBatch = `<?xml version="1.0" encoding="UTF-8"?>
+<ChangeResourceRecordSetsRequest xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+  <ChangeBatch>
+    <Changes>
+      <Change>
+        <Action>UPSERT</Action>
+        <ResourceRecordSet><Name>www.example.com</Name><Type>A</Type><TTL>300</TTL></ResourceRecordSet>
+      </Change>
+      <Change>
+        <Action>DELETE</Action>
+        <ResourceRecordSet><Name>old.example.com</Name><Type>CNAME</Type></ResourceRecordSet>
+      </Change>
+    </Changes>
+  </ChangeBatch>
+</ChangeResourceRecordSetsRequest>`
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithoutFailingUnhandledRequests(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Hostname: "route53.amazonaws.com",
+				JMESPathMatches: map[string]any{
+					"ChangeResourceRecordSetsRequest.ChangeBatch.Changes.Change[0].ResourceRecordSet.Name":      "www.example.com",
+					"ChangeResourceRecordSetsRequest.ChangeBatch.Changes.Change[?Action=='DELETE'] | length(@)": 1,
+				},
+			},
+			Response: &awsmocker.MockedResponse{
+				Body:        "<ChangeResourceRecordSetsResponse/>",
+				ContentType: awsmocker.ContentTypeXML,
+			},
+		},
+	))
+
+	client := &http.Client{
+		Transport: &http.Transport{Proxy: m.Proxy()},
+	}
+
+	send := func(body string) int {
+		resp, err := client.Post("http://route53.amazonaws.com/2013-04-01/hostedzone/Z1/rrset/", "text/xml", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, send(changeBatch))
+	require.Equal(t, http.StatusNotImplemented, send(strings.ReplaceAll(changeBatch, "www.example.com", "api.example.com")))
+	require.Equal(t, http.StatusNotImplemented, send("not xml"))
+
+	requests := m.Requests()
+	require.Len(t, requests, 3)
+	require.Equal(t, "https://route53.amazonaws.com/doc/2013-04-01/", requests[0].XmlPayload["ChangeResourceRecordSetsRequest"].(map[string]any)["-xmlns"])
+	require.Nil(t, requests[2].XmlPayload)
+}
+
+func TestXmlPayloadSingleListItem(t *testing.T) {
+	const changeBatch = `<ChangeResourceRecordSetsRequest xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+  <ChangeBatch>
+    <Changes>
+      <Change>
+        <Action>UPSERT</Action>
+        <ResourceRecordSet><Name>www.example.com</Name><Type>A</Type></ResourceRecordSet>
+      </Change>
+    </Changes>
+  </ChangeBatch>
+</ChangeResourceRecordSetsRequest>`
+
+	m := awsmocker.Start(t, awsmocker.WithoutDefaultMocks(), awsmocker.WithMocks(
+		&awsmocker.MockedEndpoint{
+			Request: &awsmocker.MockedRequest{
+				Hostname: "route53.amazonaws.com",
+				JMESPathMatches: map[string]any{
+					"ChangeResourceRecordSetsRequest.ChangeBatch.Changes.Change[0].Action": "UPSERT",
+				},
+			},
+			Response: &awsmocker.MockedResponse{
+				Body:        "<ChangeResourceRecordSetsResponse/>",
+				ContentType: awsmocker.ContentTypeXML,
+			},
+		},
+	))
+
+	client := &http.Client{
+		Transport: &http.Transport{Proxy: m.Proxy()},
+	}
+
+	resp, err := client.Post("http://route53.amazonaws.com/2013-04-01/hostedzone/Z1/rrset/", "text/xml", strings.NewReader(changeBatch))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	changes := m.Requests()[0].XmlPayload["ChangeResourceRecordSetsRequest"].(map[string]any)["ChangeBatch"].(map[string]any)["Changes"].(map[string]any)
+	require.Len(t, changes["Change"], 1)
+}